@@ -1,6 +1,7 @@
 package goMXP
 
 import (
+	"context"
 	"crypto/sha512"
 	"encoding/hex"
 	"fmt"
@@ -56,8 +57,13 @@ Parameters:
 		Any MXP public address.
 */
 func (t *GoMXP) Balance(blockhash, address string) (*big.Int, error) {
-	query := fmt.Sprintf("/chains/main/blocks/%s/context/contracts/%s/balance", blockhash, address)
-	resp, err := t.get(query)
+	return t.BalanceContext(context.Background(), blockhash, address)
+}
+
+// BalanceContext is Balance bound to ctx for cancellation and deadlines.
+func (t *GoMXP) BalanceContext(ctx context.Context, blockhash, address string) (*big.Int, error) {
+	query := fmt.Sprintf("%s/blocks/%s/context/contracts/%s/balance", t.chainPath(ctx), blockhash, address)
+	resp, err := t.get(ctx, query)
 	if err != nil {
 		return big.NewInt(0), errors.Wrap(err, "failed to get balance")
 	}
@@ -1,10 +1,12 @@
 package goMXP
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -80,7 +82,7 @@ type Block struct {
 }
 
 /*
-Header represents the header in a MXP block
+Header represents the header in a MXP block. Protocol, ChainID and Hash are only set by GoMXP.Header.
 
 RPC:
 	/chains/<chain_id>/blocks/<block_id> (<dyn>)
@@ -89,6 +91,9 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-balance
 */
 type Header struct {
+	Protocol         string    `json:"protocol,omitempty"`
+	ChainID          string    `json:"chain_id,omitempty"`
+	Hash             string    `json:"hash,omitempty"`
 	Level            int       `json:"level"`
 	Proto            int       `json:"proto"`
 	Predecessor      string    `json:"Predecessor"`
@@ -99,6 +104,7 @@ type Header struct {
 	Context          string    `json:"context"`
 	Priority         int       `json:"priority"`
 	ProofOfWorkNonce string    `json:"proof_of_work_nonce"`
+	SeedNonceHash    string    `json:"seed_nonce_hash,omitempty"`
 	Signature        string    `json:"signature"`
 }
 
@@ -203,11 +209,14 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-block-id-context-contracts-contract-id-balance
 */
 type OperationResult struct {
-	BalanceUpdates      []BalanceUpdates `json:"balance_updates"`
-	OriginatedContracts []string         `json:"originated_contracts"`
-	Status              string           `json:"status"`
-	ConsumedGas         *Int             `json:"consumed_gas,omitempty"`
-	Errors              []Error          `json:"errors,omitempty"`
+	BalanceUpdates               []BalanceUpdates `json:"balance_updates"`
+	OriginatedContracts          []string         `json:"originated_contracts"`
+	Status                       string           `json:"status"`
+	ConsumedGas                  *Int             `json:"consumed_gas,omitempty"`
+	StorageSize                  *Int             `json:"storage_size,omitempty"`
+	PaidStorageSizeDiff          *Int             `json:"paid_storage_size_diff,omitempty"`
+	AllocatedDestinationContract bool             `json:"allocated_destination_contract,omitempty"`
+	Errors                       []Error          `json:"errors,omitempty"`
 }
 
 /*
@@ -248,6 +257,7 @@ type Contents struct {
 	Destination      string            `json:"destination,omitempty"`
 	Delegate         string            `json:"delegate,omitempty"`
 	Phk              string            `json:"phk,omitempty"`
+	PublicKey        string            `json:"public_key,omitempty"`
 	Secret           string            `json:"secret,omitempty"`
 	Level            int               `json:"level,omitempty"`
 	ManagerPublicKey string            `json:"managerPubkey,omitempty"`
@@ -256,9 +266,28 @@ type Contents struct {
 	Proposal         string            `json:"proposal,omitempty"`
 	Proposals        []string          `json:"proposals,omitempty"`
 	Ballot           string            `json:"ballot,omitempty"`
+	Parameters       *Parameters       `json:"parameters,omitempty"`
+	Script           *Script           `json:"script,omitempty"`
 	Metadata         *ContentsMetadata `json:"metadata,omitempty"`
 }
 
+/*
+Parameters represents the parameters of a transaction to a smart contract: the entrypoint it calls
+and the Micheline value it is called with.
+*/
+type Parameters struct {
+	Entrypoint string          `json:"entrypoint"`
+	Value      json.RawMessage `json:"value"`
+}
+
+/*
+Script represents the Micheline code and initial storage of an origination.
+*/
+type Script struct {
+	Code    json.RawMessage `json:"code"`
+	Storage json.RawMessage `json:"storage"`
+}
+
 func (c *Contents) equal(contents Contents) (bool, error) {
 	x, err := json.Marshal(c)
 	if err != nil {
@@ -289,6 +318,7 @@ Link:
 type ContentsMetadata struct {
 	BalanceUpdates           []BalanceUpdates            `json:"balance_updates"`
 	OperationResult          *OperationResult            `json:"operation_result,omitempty"`
+	Delegate                 string                      `json:"delegate,omitempty"`
 	Slots                    []int                       `json:"slots"`
 	InternalOperationResults []*InternalOperationResults `json:"internal_operation_results,omitempty"`
 }
@@ -335,7 +365,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-chains-chain-id-blocks
 */
 func (t *GoMXP) Head() (*Block, error) {
-	resp, err := t.get("/chains/main/blocks/head")
+	return t.HeadContext(context.Background())
+}
+
+// HeadContext is Head bound to ctx for cancellation and deadlines.
+func (t *GoMXP) HeadContext(ctx context.Context) (*Block, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/head", t.chainPath(ctx)))
 	if err != nil {
 		return &Block{}, errors.Wrapf(err, "could not get head block")
 	}
@@ -364,12 +399,17 @@ Parameters:
 		level = <int> : The block level.
 */
 func (t *GoMXP) Block(id interface{}) (*Block, error) {
+	return t.BlockContext(context.Background(), id)
+}
+
+// BlockContext is Block bound to ctx for cancellation and deadlines.
+func (t *GoMXP) BlockContext(ctx context.Context, id interface{}) (*Block, error) {
 	blockID, err := idToString(id)
 	if err != nil {
 		return &Block{}, errors.Wrapf(err, "could not get block '%s'", blockID)
 	}
 
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s", blockID))
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s", t.chainPath(ctx), blockID))
 	if err != nil {
 		return &Block{}, errors.Wrapf(err, "could not get block '%s'", blockID)
 	}
@@ -397,7 +437,12 @@ Parameters:
 		The hash of block (height) of which you want to make the query.
 */
 func (t *GoMXP) OperationHashes(blockhash string) ([][]string, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/operation_hashes", blockhash))
+	return t.OperationHashesContext(context.Background(), blockhash)
+}
+
+// OperationHashesContext is OperationHashes bound to ctx for cancellation and deadlines.
+func (t *GoMXP) OperationHashesContext(ctx context.Context, blockhash string) ([][]string, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/operation_hashes", t.chainPath(ctx), blockhash))
 	if err != nil {
 		return [][]string{}, errors.Wrapf(err, "could not get operation hashes")
 	}
@@ -411,6 +456,234 @@ func (t *GoMXP) OperationHashes(blockhash string) ([][]string, error) {
 	return operations, nil
 }
 
+/*
+Header gets the header of a block, without its operations and metadata.
+
+Path:
+	../<block_id>/header (GET)
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-block-id-header
+
+Parameters:
+
+	id:
+		hash = <string> : The block hash.
+		level = <int> : The block level.
+*/
+func (t *GoMXP) Header(id interface{}) (*Header, error) {
+	return t.HeaderContext(context.Background(), id)
+}
+
+// HeaderContext is Header bound to ctx for cancellation and deadlines.
+func (t *GoMXP) HeaderContext(ctx context.Context, id interface{}) (*Header, error) {
+	var header Header
+	if err := t.blockResource(ctx, id, "header", "header", &header); err != nil {
+		return &Header{}, err
+	}
+
+	return &header, nil
+}
+
+/*
+HeaderShell gets the shell header of a block: the header without the protocol data (priority, proof
+of work nonce, seed nonce hash and signature).
+
+Path:
+	../<block_id>/header/shell (GET)
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-block-id-header-shell
+
+Parameters:
+
+	id:
+		hash = <string> : The block hash.
+		level = <int> : The block level.
+*/
+func (t *GoMXP) HeaderShell(id interface{}) (*Header, error) {
+	return t.HeaderShellContext(context.Background(), id)
+}
+
+// HeaderShellContext is HeaderShell bound to ctx for cancellation and deadlines.
+func (t *GoMXP) HeaderShellContext(ctx context.Context, id interface{}) (*Header, error) {
+	var header Header
+	if err := t.blockResource(ctx, id, "header/shell", "shell header", &header); err != nil {
+		return &Header{}, err
+	}
+
+	return &header, nil
+}
+
+/*
+Metadata gets the metadata of a block, including its balance updates.
+
+Path:
+	../<block_id>/metadata (GET)
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-block-id-metadata
+
+Parameters:
+
+	id:
+		hash = <string> : The block hash.
+		level = <int> : The block level.
+*/
+func (t *GoMXP) Metadata(id interface{}) (*Metadata, error) {
+	return t.MetadataContext(context.Background(), id)
+}
+
+// MetadataContext is Metadata bound to ctx for cancellation and deadlines.
+func (t *GoMXP) MetadataContext(ctx context.Context, id interface{}) (*Metadata, error) {
+	var metadata Metadata
+	if err := t.blockResource(ctx, id, "metadata", "metadata", &metadata); err != nil {
+		return &Metadata{}, err
+	}
+
+	return &metadata, nil
+}
+
+/*
+Hash gets the hash of a block, such as the hash of head or of a level.
+
+Path:
+	../<block_id>/hash (GET)
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-block-id-hash
+
+Parameters:
+
+	id:
+		hash = <string> : The block hash.
+		level = <int> : The block level.
+*/
+func (t *GoMXP) Hash(id interface{}) (string, error) {
+	return t.HashContext(context.Background(), id)
+}
+
+// HashContext is Hash bound to ctx for cancellation and deadlines.
+func (t *GoMXP) HashContext(ctx context.Context, id interface{}) (string, error) {
+	var hash string
+	if err := t.blockResource(ctx, id, "hash", "hash", &hash); err != nil {
+		return "", err
+	}
+
+	return hash, nil
+}
+
+/*
+OperationsAtPass gets the operations of a block in a validation pass (see ENDORSEMENTPASS, VOTEPASS,
+ANONYMOUSPASS and MANAGERPASS).
+
+Path:
+	../<block_id>/operations/<list_offset> (GET)
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-block-id-operations-list-offset
+
+Parameters:
+
+	id:
+		hash = <string> : The block hash.
+		level = <int> : The block level.
+
+	pass:
+		The validation pass.
+*/
+func (t *GoMXP) OperationsAtPass(id interface{}, pass int) ([]Operations, error) {
+	return t.OperationsAtPassContext(context.Background(), id, pass)
+}
+
+// OperationsAtPassContext is OperationsAtPass bound to ctx for cancellation and deadlines.
+func (t *GoMXP) OperationsAtPassContext(ctx context.Context, id interface{}, pass int) ([]Operations, error) {
+	var operations []Operations
+	if err := t.blockResource(ctx, id, fmt.Sprintf("operations/%d", pass), fmt.Sprintf("operations at pass %d", pass), &operations); err != nil {
+		return []Operations{}, err
+	}
+
+	return operations, nil
+}
+
+/*
+Operation gets a single operation of a block by its validation pass and its index in the pass.
+
+Path:
+	../<block_id>/operations/<list_offset>/<operation_offset> (GET)
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-block-id-operations-list-offset-operation-offset
+
+Parameters:
+
+	id:
+		hash = <string> : The block hash.
+		level = <int> : The block level.
+
+	pass:
+		The validation pass.
+
+	index:
+		The index of the operation in the validation pass.
+*/
+func (t *GoMXP) Operation(id interface{}, pass, index int) (*Operations, error) {
+	return t.OperationContext(context.Background(), id, pass, index)
+}
+
+// OperationContext is Operation bound to ctx for cancellation and deadlines.
+func (t *GoMXP) OperationContext(ctx context.Context, id interface{}, pass, index int) (*Operations, error) {
+	var operation Operations
+	if err := t.blockResource(ctx, id, fmt.Sprintf("operations/%d/%d", pass, index), fmt.Sprintf("operation %d at pass %d", index, pass), &operation); err != nil {
+		return &Operations{}, err
+	}
+
+	return &operation, nil
+}
+
+/*
+LiveBlocks gets the hashes of the blocks an operation can be branched from to be included in the block
+after a block.
+
+Path:
+	../<block_id>/live_blocks (GET)
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-block-id-live-blocks
+
+Parameters:
+
+	id:
+		hash = <string> : The block hash.
+		level = <int> : The block level.
+*/
+func (t *GoMXP) LiveBlocks(id interface{}) ([]string, error) {
+	return t.LiveBlocksContext(context.Background(), id)
+}
+
+// LiveBlocksContext is LiveBlocks bound to ctx for cancellation and deadlines.
+func (t *GoMXP) LiveBlocksContext(ctx context.Context, id interface{}) ([]string, error) {
+	var liveBlocks []string
+	if err := t.blockResource(ctx, id, "live_blocks", "live blocks", &liveBlocks); err != nil {
+		return []string{}, err
+	}
+
+	return liveBlocks, nil
+}
+
+// blockResource gets ../<block_id>/<resource> and unmarshals it into out. name describes the resource in errors.
+func (t *GoMXP) blockResource(ctx context.Context, id interface{}, resource, name string, out interface{}) error {
+	blockID, err := idToString(id)
+	if err != nil {
+		return errors.Wrapf(err, "could not get %s of block '%s'", name, blockID)
+	}
+
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/%s", t.chainPath(ctx), blockID, resource))
+	if err != nil {
+		return errors.Wrapf(err, "could not get %s of block '%s'", name, blockID)
+	}
+
+	err = json.Unmarshal(resp, out)
+	if err != nil {
+		return errors.Wrapf(err, "could not unmarshal %s of block '%s'", name, blockID)
+	}
+
+	return nil
+}
+
 func idToString(id interface{}) (string, error) {
 	switch v := id.(type) {
 	case int:
@@ -421,3 +694,8 @@ func idToString(id interface{}) (string, error) {
 		return "", errors.Errorf("id must be block level (int) or block hash (string)")
 	}
 }
+
+// isBlockHash reports whether id is a block hash rather than a relative block ID such as head or a level.
+func isBlockHash(id string) bool {
+	return len(id) == 51 && strings.HasPrefix(id, "B")
+}
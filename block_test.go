@@ -1,8 +1,13 @@
 package goMXP
 
 import (
+	"context"
+	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -159,6 +164,129 @@ func Test_OperationHashes(t *testing.T) {
 	}
 }
 
+// blockResourceHandlerMock serves the sub-resources of block.json at /chains/main/blocks/<id>/<resource>.
+func blockResourceHandlerMock(requested *[]string) http.HandlerFunc {
+	goldenBlock := getResponse(block).(*Block)
+	path := regexp.MustCompile(`^/chains/main/blocks/[^/]+/(.+)$`)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		match := path.FindStringSubmatch(r.URL.Path)
+		if match == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		*requested = append(*requested, match[1])
+
+		var out interface{}
+		switch resource := strings.Split(match[1], "/"); resource[0] {
+		case "header":
+			header := goldenBlock.Header
+			if len(resource) == 1 {
+				header.Protocol, header.ChainID, header.Hash = goldenBlock.Protocol, goldenBlock.ChainID, goldenBlock.Hash
+			} else {
+				header.Priority, header.ProofOfWorkNonce, header.Signature = 0, "", ""
+			}
+			out = header
+		case "metadata":
+			out = goldenBlock.Metadata
+		case "hash":
+			out = goldenBlock.Hash
+		case "live_blocks":
+			out = []string{goldenBlock.Header.Predecessor, goldenBlock.Hash}
+		case "operations":
+			pass, _ := strconv.Atoi(resource[1])
+			if len(resource) == 2 {
+				out = goldenBlock.Operations[pass]
+			} else if index, _ := strconv.Atoi(resource[2]); index < len(goldenBlock.Operations[pass]) {
+				out = goldenBlock.Operations[pass][index]
+			} else {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write(readResponse(rpcerrors))
+				return
+			}
+		case "junk":
+			w.Write([]byte(`junk`))
+			return
+		}
+
+		json.NewEncoder(w).Encode(out)
+	}
+}
+
+func Test_blockResources(t *testing.T) {
+	goldenBlock := getResponse(block).(*Block)
+
+	var requested []string
+	server := httptest.NewServer(blockResourceHandlerMock(&requested))
+	defer server.Close()
+
+	gt, err := New(server.URL, WithLazyConstants())
+	assert.Nil(t, err)
+
+	t.Run("gets the header", func(t *testing.T) {
+		header, err := gt.Header(goldenBlock.Hash)
+		assert.Nil(t, err)
+		assert.Equal(t, goldenBlock.Hash, header.Hash)
+		assert.Equal(t, goldenBlock.ChainID, header.ChainID)
+		assert.Equal(t, goldenBlock.Header.Level, header.Level)
+		assert.Equal(t, goldenBlock.Header.Signature, header.Signature)
+
+		hash, err := BlockHash(*header)
+		assert.Nil(t, err)
+		assert.Equal(t, goldenBlock.Hash, hash)
+	})
+
+	t.Run("gets the shell header", func(t *testing.T) {
+		header, err := gt.HeaderShell(goldenBlock.Header.Level)
+		assert.Nil(t, err)
+		assert.Equal(t, goldenBlock.Header.Context, header.Context)
+		assert.Equal(t, "", header.Signature)
+		assert.Equal(t, "header/shell", requested[len(requested)-1])
+	})
+
+	t.Run("gets the metadata", func(t *testing.T) {
+		metadata, err := gt.Metadata("head")
+		assert.Nil(t, err)
+		assert.Equal(t, goldenBlock.Metadata.Baker, metadata.Baker)
+		assert.Len(t, metadata.BalanceUpdates, 3)
+	})
+
+	t.Run("gets the hash", func(t *testing.T) {
+		hash, err := gt.Hash("head~2")
+		assert.Nil(t, err)
+		assert.Equal(t, goldenBlock.Hash, hash)
+	})
+
+	t.Run("gets operations", func(t *testing.T) {
+		operations, err := gt.OperationsAtPass("head", ENDORSEMENTPASS)
+		assert.Nil(t, err)
+		assert.Len(t, operations, 20)
+
+		operation, err := gt.Operation("head", MANAGERPASS, 1)
+		assert.Nil(t, err)
+		assert.Equal(t, "onpjUwLcwfCCQyy2ndNvrTF2W64i782EzqFRHUqWkWQJik26eDq", operation.Hash)
+		assert.Equal(t, "operations/3/1", requested[len(requested)-1])
+
+		_, err = gt.Operation("head", MANAGERPASS, 100)
+		checkErr(t, true, "could not get operation 100 at pass 3 of block 'head'", err)
+	})
+
+	t.Run("gets live blocks", func(t *testing.T) {
+		liveBlocks, err := gt.LiveBlocks("head")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{goldenBlock.Header.Predecessor, goldenBlock.Hash}, liveBlocks)
+	})
+
+	t.Run("handles bad ids and responses", func(t *testing.T) {
+		header, err := gt.Header(45.433)
+		checkErr(t, true, "could not get header of block ''", err)
+		assert.Equal(t, &Header{}, header)
+
+		err = gt.blockResource(context.Background(), "head", "junk", "junk", &header)
+		checkErr(t, true, "could not unmarshal junk of block 'head'", err)
+	})
+}
+
 func Test_idToString(t *testing.T) {
 	cases := []struct {
 		name    string
@@ -194,3 +322,43 @@ func Test_idToString(t *testing.T) {
 		})
 	}
 }
+
+func BenchmarkBlock_backfill(b *testing.B) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(readResponse(block))
+	}))
+	defer server.Close()
+	tlsConfig := server.Client().Transport.(*http.Transport).TLSClientConfig
+
+	// closeIdleConnections drops connections after every call, as GoMXP did before it kept connections alive.
+	closeIdleConnections := func(gt *GoMXP) Middleware {
+		return func(next RPCHandler) RPCHandler {
+			return func(call *RPCCall) {
+				next(call)
+				gt.config().client.CloseIdleConnections()
+			}
+		}
+	}
+
+	bench := func(b *testing.B, closeIdle bool) {
+		gt, err := New(server.URL, WithLazyConstants(), WithTLSConfig(tlsConfig))
+		if err != nil {
+			b.Fatal(err)
+		}
+		if closeIdle {
+			gt.Use(closeIdleConnections(gt))
+		}
+
+		b.ResetTimer()
+		b.RunParallel(func(pb *testing.PB) {
+			for level := 1; pb.Next(); level++ {
+				if _, err := gt.Block(level); err != nil {
+					b.Error(err)
+				}
+			}
+		})
+	}
+
+	b.Run("keep-alive", func(b *testing.B) { bench(b, false) })
+	b.Run("close idle connections", func(b *testing.B) { bench(b, true) })
+}
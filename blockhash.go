@@ -0,0 +1,162 @@
+package goMXP
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+/*
+BlockHashError is returned by Block.VerifyHash when the hash of the header of a block does not match
+the hash the node returned for it.
+*/
+type BlockHashError struct {
+	Level    int
+	Hash     string
+	Computed string
+}
+
+func (b *BlockHashError) Error() string {
+	return fmt.Sprintf("hash of block at level %d does not match its header: node returned '%s', header hashes to '%s'", b.Level, b.Hash, b.Computed)
+}
+
+/*
+ForgeBlockHeader forges a block header locally into its binary shell encoding, including the protocol
+data (priority, proof of work nonce, seed nonce hash and signature). Returns the hex encoded header.
+
+Parameters:
+
+	header:
+		The header to forge.
+*/
+func ForgeBlockHeader(header Header) (string, error) {
+	byts, err := forgeBlockHeader(header)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(byts), nil
+}
+
+/*
+BlockHash computes the hash of a block locally from its header: the blake2b digest of the forged
+header, base58 encoded with the B prefix.
+
+Parameters:
+
+	header:
+		The header of the block.
+*/
+func BlockHash(header Header) (string, error) {
+	byts, err := forgeBlockHeader(header)
+	if err != nil {
+		return "", err
+	}
+
+	hash := blake2b.Sum256(byts)
+	return b58cencode(hash[:], branchprefix), nil
+}
+
+/*
+VerifyHash checks that the hash of the header of the block matches b.Hash. Returns a *BlockHashError
+if it does not.
+*/
+func (b *Block) VerifyHash() error {
+	hash, err := BlockHash(b.Header)
+	if err != nil {
+		return errors.Wrapf(err, "failed to verify hash of block '%s'", b.Hash)
+	}
+
+	if hash != b.Hash {
+		return &BlockHashError{Level: b.Header.Level, Hash: b.Hash, Computed: hash}
+	}
+
+	return nil
+}
+
+func forgeBlockHeader(header Header) ([]byte, error) {
+	var buf bytes.Buffer
+
+	binary.Write(&buf, binary.BigEndian, int32(header.Level))
+	buf.WriteByte(byte(header.Proto))
+
+	predecessor, err := decodePrefixed(header.Predecessor, 32, branchprefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to forge block header: invalid predecessor")
+	}
+	buf.Write(predecessor)
+
+	binary.Write(&buf, binary.BigEndian, header.Timestamp.Unix())
+	buf.WriteByte(byte(header.ValidationPass))
+
+	operationsHash, err := decodePrefixed(header.OperationsHash, 32, operationlisthashprefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to forge block header: invalid operations hash")
+	}
+	buf.Write(operationsHash)
+
+	var fitness bytes.Buffer
+	for _, f := range header.Fitness {
+		v, err := hex.DecodeString(f)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to forge block header: invalid fitness '%s'", f)
+		}
+		binary.Write(&fitness, binary.BigEndian, uint32(len(v)))
+		fitness.Write(v)
+	}
+	binary.Write(&buf, binary.BigEndian, uint32(fitness.Len()))
+	buf.Write(fitness.Bytes())
+
+	context, err := decodePrefixed(header.Context, 32, contextprefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to forge block header: invalid context")
+	}
+	buf.Write(context)
+
+	binary.Write(&buf, binary.BigEndian, uint16(header.Priority))
+
+	nonce, err := hex.DecodeString(header.ProofOfWorkNonce)
+	if err != nil || len(nonce) != 8 {
+		return nil, errors.Errorf("failed to forge block header: invalid proof of work nonce '%s'", header.ProofOfWorkNonce)
+	}
+	buf.Write(nonce)
+
+	if header.SeedNonceHash == "" {
+		buf.WriteByte(0)
+	} else {
+		seedNonceHash, err := decodePrefixed(header.SeedNonceHash, 32, noncehashprefix)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to forge block header: invalid seed nonce hash")
+		}
+		buf.WriteByte(0xff)
+		buf.Write(seedNonceHash)
+	}
+
+	signature, err := decodePrefixed(header.Signature, 64, sigprefix, edsigprefix, spsigprefix, p2sigprefix)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to forge block header: invalid signature")
+	}
+	buf.Write(signature)
+
+	return buf.Bytes(), nil
+}
+
+// decodePrefixed decodes a base58check string with one of prefixes and a payload of length bytes.
+func decodePrefixed(encoded string, length int, prefixes ...prefix) ([]byte, error) {
+	data, err := decode(encoded)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode '%s'", encoded)
+	}
+
+	for _, p := range prefixes {
+		if len(data) == len(p)+length && bytes.HasPrefix(data, p) {
+			return data[len(p):], nil
+		}
+	}
+
+	return nil, errors.Errorf("'%s' is not a %d byte payload with an expected prefix", encoded, length)
+}
@@ -0,0 +1,104 @@
+package goMXP
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_BlockHash(t *testing.T) {
+	goldenBlock := getResponse(block).(*Block)
+
+	type want struct {
+		err         bool
+		errContains string
+		hash        string
+	}
+
+	cases := []struct {
+		name  string
+		input func(h Header) Header
+		want  want
+	}{
+		{
+			"is successful",
+			func(h Header) Header { return h },
+			want{false, "", "BLfEWKVudXH15N8nwHZehyLNjRuNLoJavJDjSZ7nq8ggfzbZ18p"},
+		},
+		{
+			"changes with the header",
+			func(h Header) Header { h.Priority = 1; return h },
+			want{false, "", "BKwJVqfdi1EcAsYUm5CMAatfXejMQQc3gThqvfew9H7hvXuHJ6h"},
+		},
+		{
+			"handles invalid predecessor",
+			func(h Header) Header {
+				h.Predecessor = "LLoZr4zsAszKDFvST1xRCF7LJ8h4sGdUfVGFCLJFKznnz4gLYfcnT"
+				return h
+			},
+			want{true, "failed to forge block header: invalid predecessor", ""},
+		},
+		{
+			"handles invalid fitness",
+			func(h Header) Header { h.Fitness = []string{"0x"}; return h },
+			want{true, "failed to forge block header: invalid fitness '0x'", ""},
+		},
+		{
+			"handles invalid proof of work nonce",
+			func(h Header) Header { h.ProofOfWorkNonce = "00"; return h },
+			want{true, "failed to forge block header: invalid proof of work nonce '00'", ""},
+		},
+		{
+			"handles invalid signature",
+			func(h Header) Header { h.Signature = ""; return h },
+			want{true, "failed to forge block header: invalid signature", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := BlockHash(tt.input(goldenBlock.Header))
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			assert.Equal(t, tt.want.hash, hash)
+		})
+	}
+}
+
+func Test_ForgeBlockHeader(t *testing.T) {
+	header := getResponse(block).(*Block).Header
+
+	forged, err := ForgeBlockHeader(header)
+	assert.Nil(t, err)
+	assert.Equal(t, "000cd00105", forged[:10])
+	assert.Equal(t, "0000"+"9498d2cc86310000"+"00", forged[len(forged)-128-22:len(forged)-128])
+
+	header.SeedNonceHash = b58cencode(make([]byte, 32), noncehashprefix)
+	withSeedNonceHash, err := ForgeBlockHeader(header)
+	assert.Nil(t, err)
+	assert.Len(t, withSeedNonceHash, len(forged)+64)
+	assert.Contains(t, withSeedNonceHash, "9498d2cc86310000ff"+"0000000000000000000000000000000000000000000000000000000000000000")
+
+	header.SeedNonceHash = header.Context
+	_, err = ForgeBlockHeader(header)
+	checkErr(t, true, "failed to forge block header: invalid seed nonce hash", err)
+}
+
+func Test_Block_VerifyHash(t *testing.T) {
+	goldenBlock := getResponse(block).(*Block)
+	assert.Nil(t, goldenBlock.VerifyHash())
+
+	tampered := *goldenBlock
+	tampered.Header.Level++
+	err := tampered.VerifyHash()
+	var hashErr *BlockHashError
+	if assert.True(t, errors.As(err, &hashErr)) {
+		assert.Equal(t, goldenBlock.Hash, hashErr.Hash)
+		assert.Equal(t, 839682, hashErr.Level)
+		assert.NotEqual(t, goldenBlock.Hash, hashErr.Computed)
+	}
+	checkErr(t, true, "hash of block at level 839682 does not match its header", err)
+
+	tampered.Header.Context = ""
+	checkErr(t, true, "failed to verify hash of block 'BLfEWKVudXH15N8nwHZehyLNjRuNLoJavJDjSZ7nq8ggfzbZ18p'", tampered.VerifyHash())
+}
@@ -0,0 +1,262 @@
+package goMXP
+
+const (
+	// ENDORSEMENTPASS is the validation pass of endorsements
+	ENDORSEMENTPASS = 0
+	// VOTEPASS is the validation pass of proposals and ballots
+	VOTEPASS = 1
+	// ANONYMOUSPASS is the validation pass of anonymous operations (activations, nonce revelations and evidence)
+	ANONYMOUSPASS = 2
+	// MANAGERPASS is the validation pass of manager operations (transactions, originations, delegations and reveals)
+	MANAGERPASS = 3
+)
+
+/*
+BlockOperation locates one entry of the contents of an operation in a block and carries its status.
+
+Hash is the hash of the parent operation, Pass its validation pass, Index its index in the pass and
+ContentsIndex the index of the entry in the contents of the operation. Status is the status of the
+operation result (applied, failed, backtracked or skipped), or applied for operations without a
+result, which cannot fail once included in a block. It is empty when the block has no metadata.
+*/
+type BlockOperation struct {
+	Hash          string
+	Pass          int
+	Index         int
+	ContentsIndex int
+	Status        string
+	Errors        []Error
+}
+
+// Applied returns true if the operation was applied.
+func (b *BlockOperation) Applied() bool {
+	return b.Status == "applied"
+}
+
+// Endorsement is an endorsement in a block.
+type Endorsement struct {
+	BlockOperation
+	Level    int
+	Delegate string
+	Slots    []int
+}
+
+// Vote is a proposals or ballot operation in a block.
+type Vote struct {
+	BlockOperation
+	Kind      string
+	Source    string
+	Period    int
+	Proposal  string
+	Proposals []string
+	Ballot    string
+}
+
+// AnonymousOperation is an operation of the anonymous validation pass in a block.
+type AnonymousOperation struct {
+	BlockOperation
+	Kind     string
+	Contents Contents
+}
+
+// ManagerOperation is the part common to every manager operation in a block.
+type ManagerOperation struct {
+	BlockOperation
+	Kind         string
+	Source       string
+	Fee          *Int
+	Counter      *Int
+	GasLimit     *Int
+	StorageLimit *Int
+	Result       *OperationResult
+}
+
+// Transaction is a transaction in a block.
+type Transaction struct {
+	ManagerOperation
+	Amount      *Int
+	Destination string
+}
+
+// Origination is an origination in a block.
+type Origination struct {
+	ManagerOperation
+	Balance             *Int
+	Delegate            string
+	OriginatedContracts []string
+}
+
+// Delegation is a delegation in a block. Delegate is empty when the delegation is withdrawn.
+type Delegation struct {
+	ManagerOperation
+	Delegate string
+}
+
+// Reveal is a reveal in a block.
+type Reveal struct {
+	ManagerOperation
+	PublicKey string
+}
+
+// Endorsements returns the endorsements of the block.
+func (b *Block) Endorsements() []Endorsement {
+	var endorsements []Endorsement
+	b.each(ENDORSEMENTPASS, func(op BlockOperation, c Contents) {
+		if c.Kind != ENDORSEMENTOP {
+			return
+		}
+
+		endorsement := Endorsement{BlockOperation: op, Level: c.Level}
+		if c.Metadata != nil {
+			endorsement.Delegate = c.Metadata.Delegate
+			endorsement.Slots = c.Metadata.Slots
+		}
+		endorsements = append(endorsements, endorsement)
+	})
+
+	return endorsements
+}
+
+// Votes returns the proposals and ballots of the block.
+func (b *Block) Votes() []Vote {
+	var votes []Vote
+	b.each(VOTEPASS, func(op BlockOperation, c Contents) {
+		votes = append(votes, Vote{
+			BlockOperation: op,
+			Kind:           c.Kind,
+			Source:         c.Source,
+			Period:         c.Period,
+			Proposal:       c.Proposal,
+			Proposals:      c.Proposals,
+			Ballot:         c.Ballot,
+		})
+	})
+
+	return votes
+}
+
+// Anonymous returns the activations, seed nonce revelations and evidence of the block.
+func (b *Block) Anonymous() []AnonymousOperation {
+	var anonymous []AnonymousOperation
+	b.each(ANONYMOUSPASS, func(op BlockOperation, c Contents) {
+		anonymous = append(anonymous, AnonymousOperation{BlockOperation: op, Kind: c.Kind, Contents: c})
+	})
+
+	return anonymous
+}
+
+// ManagerOperations returns every manager operation of the block, whatever its kind.
+func (b *Block) ManagerOperations() []ManagerOperation {
+	var managerOperations []ManagerOperation
+	b.each(MANAGERPASS, func(op BlockOperation, c Contents) {
+		managerOperations = append(managerOperations, newManagerOperation(op, c))
+	})
+
+	return managerOperations
+}
+
+// Transactions returns the transactions of the block.
+func (b *Block) Transactions() []Transaction {
+	var transactions []Transaction
+	b.each(MANAGERPASS, func(op BlockOperation, c Contents) {
+		if c.Kind != TRANSACTIONOP {
+			return
+		}
+
+		transactions = append(transactions, Transaction{
+			ManagerOperation: newManagerOperation(op, c),
+			Amount:           c.Amount,
+			Destination:      c.Destination,
+		})
+	})
+
+	return transactions
+}
+
+// Originations returns the originations of the block.
+func (b *Block) Originations() []Origination {
+	var originations []Origination
+	b.each(MANAGERPASS, func(op BlockOperation, c Contents) {
+		if c.Kind != ORIGINATIONOP {
+			return
+		}
+
+		origination := Origination{
+			ManagerOperation: newManagerOperation(op, c),
+			Balance:          c.Balance,
+			Delegate:         c.Delegate,
+		}
+		if origination.Result != nil {
+			origination.OriginatedContracts = origination.Result.OriginatedContracts
+		}
+		originations = append(originations, origination)
+	})
+
+	return originations
+}
+
+// Delegations returns the delegations of the block.
+func (b *Block) Delegations() []Delegation {
+	var delegations []Delegation
+	b.each(MANAGERPASS, func(op BlockOperation, c Contents) {
+		if c.Kind != DELEGATIONOP {
+			return
+		}
+
+		delegations = append(delegations, Delegation{ManagerOperation: newManagerOperation(op, c), Delegate: c.Delegate})
+	})
+
+	return delegations
+}
+
+// Reveals returns the reveals of the block.
+func (b *Block) Reveals() []Reveal {
+	var reveals []Reveal
+	b.each(MANAGERPASS, func(op BlockOperation, c Contents) {
+		if c.Kind != REVEALOP {
+			return
+		}
+
+		reveals = append(reveals, Reveal{ManagerOperation: newManagerOperation(op, c), PublicKey: c.PublicKey})
+	})
+
+	return reveals
+}
+
+// each calls fn for every entry of the contents of every operation in validation pass pass.
+func (b *Block) each(pass int, fn func(op BlockOperation, c Contents)) {
+	if pass >= len(b.Operations) {
+		return
+	}
+
+	for index, operation := range b.Operations[pass] {
+		for i, c := range operation.Contents {
+			op := BlockOperation{Hash: operation.Hash, Pass: pass, Index: index, ContentsIndex: i}
+			if c.Metadata != nil {
+				op.Status = "applied"
+				if result := c.Metadata.OperationResult; result != nil {
+					op.Status = result.Status
+					op.Errors = result.Errors
+				}
+			}
+			fn(op, c)
+		}
+	}
+}
+
+func newManagerOperation(op BlockOperation, c Contents) ManagerOperation {
+	manager := ManagerOperation{
+		BlockOperation: op,
+		Kind:           c.Kind,
+		Source:         c.Source,
+		Fee:            c.Fee,
+		Counter:        c.Counter,
+		GasLimit:       c.GasLimit,
+		StorageLimit:   c.StorageLimit,
+	}
+	if c.Metadata != nil {
+		manager.Result = c.Metadata.OperationResult
+	}
+
+	return manager
+}
@@ -0,0 +1,130 @@
+package goMXP
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Block_Endorsements(t *testing.T) {
+	goldenBlock := getResponse(block).(*Block)
+
+	endorsements := goldenBlock.Endorsements()
+	assert.Len(t, endorsements, 20)
+	assert.Equal(t, Endorsement{
+		BlockOperation: BlockOperation{Hash: "oo7SNAowCeQKbXtLWkPxRyYUY3DptoXjck4axXRdqbp4V6tru4S", Status: "applied"},
+		Level:          839680,
+		Delegate:       "tz1NpWrAyDL9k2Lmnyxcgr9xuJakbBxdq7FB",
+		Slots:          []int{14},
+	}, endorsements[0])
+	assert.Equal(t, 19, endorsements[19].Index)
+	assert.True(t, endorsements[19].Applied())
+
+	assert.Empty(t, goldenBlock.Votes())
+	assert.Empty(t, goldenBlock.Anonymous())
+	assert.Empty(t, (&Block{}).Endorsements())
+}
+
+func Test_Block_ManagerOperations(t *testing.T) {
+	goldenBlock := getResponse(block).(*Block)
+
+	managerOperations := goldenBlock.ManagerOperations()
+	assert.Len(t, managerOperations, 390)
+	assert.Equal(t, "opCe8FmSkZYCFVsZz88XCaa5eEx1J1oyc3CzfoB5rbT9rwLBX3p", managerOperations[1].Hash)
+	assert.Equal(t, 1, managerOperations[1].ContentsIndex)
+	assert.Equal(t, MANAGERPASS, managerOperations[1].Pass)
+
+	transactions := goldenBlock.Transactions()
+	assert.Len(t, transactions, 390)
+
+	statuses := map[string]int{}
+	for _, transaction := range transactions {
+		statuses[transaction.Status]++
+	}
+	assert.Equal(t, map[string]int{"applied": 362, "backtracked": 19, "skipped": 9}, statuses)
+
+	var backtracked Transaction
+	for _, transaction := range transactions {
+		if transaction.Hash == "onq5z62MJf7WvHva6PdpSBC645NzQzt7M2xL43e9NpwpPvA6Lrp" {
+			backtracked = transaction
+			break
+		}
+	}
+	assert.Equal(t, 5, backtracked.Index)
+	assert.Equal(t, 0, backtracked.ContentsIndex)
+	assert.Equal(t, "backtracked", backtracked.Status)
+	assert.False(t, backtracked.Applied())
+	assert.Equal(t, "tz1Q8QkSBS63ZQnH3fBTiAMPes9R666Rn6Sc", backtracked.Source)
+	assert.Equal(t, "tz1fCxkydFj16NzPT61cgRShtT3m1s5venW1", backtracked.Destination)
+	assert.Equal(t, NewInt(21089149), backtracked.Amount)
+	assert.Equal(t, "backtracked", backtracked.Result.Status)
+
+	assert.Empty(t, goldenBlock.Originations())
+	assert.Empty(t, goldenBlock.Delegations())
+	assert.Empty(t, goldenBlock.Reveals())
+}
+
+func Test_Block_ManagerOperations_byKind(t *testing.T) {
+	b := &Block{
+		Operations: [][]Operations{
+			{},
+			{
+				{Hash: "oVote", Contents: []Contents{{Kind: "ballot", Source: "tz1a", Period: 10, Proposal: "Pt", Ballot: "yay", Metadata: &ContentsMetadata{}}}},
+			},
+			{
+				{Hash: "oActivation", Contents: []Contents{{Kind: "activate_account", Secret: "00"}}},
+			},
+			{
+				{
+					Hash: "oBatch",
+					Contents: []Contents{
+						{Kind: REVEALOP, Source: "tz1a", PublicKey: "edpk", Metadata: &ContentsMetadata{OperationResult: &OperationResult{Status: "applied"}}},
+						{Kind: DELEGATIONOP, Source: "tz1a", Delegate: "tz1b", Metadata: &ContentsMetadata{OperationResult: &OperationResult{Status: "applied"}}},
+						{
+							Kind:     ORIGINATIONOP,
+							Source:   "tz1a",
+							Balance:  NewInt(10),
+							Metadata: &ContentsMetadata{OperationResult: &OperationResult{Status: "failed", Errors: []Error{{Kind: "temporary", ID: "gas_exhausted"}}}},
+						},
+						{Kind: ORIGINATIONOP, Source: "tz1a", Metadata: &ContentsMetadata{OperationResult: &OperationResult{Status: "applied", OriginatedContracts: []string{"KT1a"}}}},
+					},
+				},
+			},
+		},
+	}
+
+	votes := b.Votes()
+	if assert.Len(t, votes, 1) {
+		assert.Equal(t, Vote{BlockOperation: BlockOperation{Hash: "oVote", Pass: VOTEPASS, Status: "applied"}, Kind: "ballot", Source: "tz1a", Period: 10, Proposal: "Pt", Ballot: "yay"}, votes[0])
+	}
+
+	anonymous := b.Anonymous()
+	if assert.Len(t, anonymous, 1) {
+		assert.Equal(t, "activate_account", anonymous[0].Kind)
+		assert.Equal(t, "", anonymous[0].Status)
+	}
+
+	reveals := b.Reveals()
+	if assert.Len(t, reveals, 1) {
+		assert.Equal(t, "edpk", reveals[0].PublicKey)
+		assert.Equal(t, 0, reveals[0].ContentsIndex)
+	}
+
+	delegations := b.Delegations()
+	if assert.Len(t, delegations, 1) {
+		assert.Equal(t, "tz1b", delegations[0].Delegate)
+		assert.Equal(t, 1, delegations[0].ContentsIndex)
+	}
+
+	originations := b.Originations()
+	if assert.Len(t, originations, 2) {
+		assert.Equal(t, "failed", originations[0].Status)
+		assert.Equal(t, []Error{{Kind: "temporary", ID: "gas_exhausted"}}, originations[0].Errors)
+		assert.Equal(t, NewInt(10), originations[0].Balance)
+		assert.Equal(t, []string{"KT1a"}, originations[1].OriginatedContracts)
+		assert.Equal(t, 3, originations[1].ContentsIndex)
+	}
+
+	assert.Len(t, b.ManagerOperations(), 4)
+	assert.Empty(t, b.Transactions())
+}
@@ -0,0 +1,218 @@
+package goMXP
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+/*
+BlockRangeInput is the input for the goMXP.BlockRange and goMXP.BlockRangeFunc functions.
+
+Function:
+	func (t *GoMXP) BlockRange(ctx context.Context, input BlockRangeInput) (<-chan BlockRangeResult, error) {}
+*/
+type BlockRangeInput struct {
+	// The first level of the range.
+	From int `validate:"min=0"`
+
+	// The last level of the range, inclusive.
+	To int `validate:"gtefield=From"`
+
+	// How many blocks are fetched at once. Defaults to 8.
+	Workers int `validate:"min=0"`
+
+	// The last level completed by a previous call (see BlockRangeFunc). Levels up to and including it
+	// are skipped. Ignored if it is lower than From.
+	ResumeAfter int
+}
+
+/*
+BlockRangeResult is the outcome of fetching a single level of a BlockRange.
+*/
+type BlockRangeResult struct {
+	Level int
+	Block *Block
+	Err   error
+}
+
+/*
+BlockRangeError is returned by BlockRangeFunc when some levels of the range could not be fetched.
+*/
+type BlockRangeError struct {
+	Errors map[int]error
+}
+
+func (b *BlockRangeError) Error() string {
+	levels := make([]int, 0, len(b.Errors))
+	for level := range b.Errors {
+		levels = append(levels, level)
+	}
+	sort.Ints(levels)
+
+	var msgs []string
+	for _, level := range levels {
+		msgs = append(msgs, fmt.Sprintf("level %d: %s", level, b.Errors[level].Error()))
+	}
+
+	return fmt.Sprintf("failed to fetch %d level(s): %s", len(levels), strings.Join(msgs, "; "))
+}
+
+/*
+BlockRange fetches every block between input.From and input.To with a pool of input.Workers and
+delivers them in level order. A level that could not be fetched is delivered with its error and does
+not stop the range. At most input.Workers blocks are held ahead of the consumer. The channel is closed
+once the range is delivered or ctx is done.
+
+Parameters:
+
+	ctx:
+		Cancel ctx to stop fetching.
+
+	input:
+		The range of levels to fetch.
+*/
+func (t *GoMXP) BlockRange(ctx context.Context, input BlockRangeInput) (<-chan BlockRangeResult, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	from := input.From
+	if input.ResumeAfter >= from {
+		from = input.ResumeAfter + 1
+	}
+
+	workers := input.Workers
+	if workers == 0 {
+		workers = 8
+	}
+
+	results := make(chan BlockRangeResult)
+	go t.fetchRange(ctx, from, input.To, workers, results)
+
+	return results, nil
+}
+
+/*
+BlockRangeFunc is BlockRange delivering every level to fn. It stops early if fn returns an error or
+ctx is done, and otherwise returns a *BlockRangeError listing the levels that failed.
+
+The returned level is the last level up to which every level was fetched and accepted by fn, and can
+be passed as input.ResumeAfter to resume the range.
+
+Parameters:
+
+	ctx:
+		Cancel ctx to stop fetching.
+
+	input:
+		The range of levels to fetch.
+
+	fn:
+		Called with every level in order.
+*/
+func (t *GoMXP) BlockRangeFunc(ctx context.Context, input BlockRangeInput, fn func(result BlockRangeResult) error) (int, error) {
+	last := input.From - 1
+	if input.ResumeAfter > last {
+		last = input.ResumeAfter
+	}
+
+	results, err := t.BlockRange(ctx, input)
+	if err != nil {
+		return last, err
+	}
+
+	failed := map[int]error{}
+	for result := range results {
+		if result.Err != nil {
+			failed[result.Level] = result.Err
+		}
+
+		if err := fn(result); err != nil {
+			return last, errors.Wrapf(err, "failed to process level %d", result.Level)
+		}
+
+		if len(failed) == 0 {
+			last = result.Level
+		}
+	}
+
+	if ctx.Err() != nil {
+		return last, errors.Wrap(ctx.Err(), "failed to fetch block range")
+	}
+
+	if len(failed) > 0 {
+		return last, &BlockRangeError{Errors: failed}
+	}
+
+	return last, nil
+}
+
+// fetchRange fetches the levels from from to to and sends them to results in order.
+func (t *GoMXP) fetchRange(ctx context.Context, from, to, workers int, results chan<- BlockRangeResult) {
+	defer close(results)
+
+	type job struct {
+		level  int
+		result chan BlockRangeResult
+	}
+
+	jobs := make(chan job)
+	pending := make(chan chan BlockRangeResult, workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := range jobs {
+				block, err := t.BlockContext(ctx, j.level)
+				if err != nil {
+					block = nil
+				}
+				j.result <- BlockRangeResult{Level: j.level, Block: block, Err: err}
+			}
+		}()
+	}
+	defer wg.Wait()
+
+	go func() {
+		defer close(pending)
+		defer close(jobs)
+
+		for level := from; level <= to; level++ {
+			result := make(chan BlockRangeResult, 1)
+			select {
+			case pending <- result:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case jobs <- job{level: level, result: result}:
+			case <-ctx.Done():
+				result <- BlockRangeResult{Level: level, Err: ctx.Err()}
+				return
+			}
+		}
+	}()
+
+	for result := range pending {
+		r := <-result
+		if ctx.Err() != nil {
+			return
+		}
+
+		select {
+		case results <- r:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
@@ -0,0 +1,172 @@
+package goMXP
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// blockRangeHandlerMock serves block.json for every level, slower for lower levels so blocks complete
+// out of order, and fails the levels in failing.
+func blockRangeHandlerMock(requests *int32, failing ...int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		level, _ := strconv.Atoi(r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:])
+		time.Sleep(time.Duration(10-level%10) * time.Millisecond)
+
+		for _, f := range failing {
+			if f == level {
+				w.WriteHeader(http.StatusInternalServerError)
+				w.Write(readResponse(rpcerrors))
+				return
+			}
+		}
+
+		w.Write(readResponse(block))
+	}
+}
+
+func Test_BlockRange(t *testing.T) {
+	type want struct {
+		err         bool
+		errContains string
+		levels      []int
+		failed      []int
+	}
+
+	cases := []struct {
+		name    string
+		input   BlockRangeInput
+		failing []int
+		want    want
+	}{
+		{
+			"is successful",
+			BlockRangeInput{From: 10, To: 29, Workers: 4},
+			nil,
+			want{false, "", levels(10, 29), nil},
+		},
+		{
+			"delivers failed levels without stopping",
+			BlockRangeInput{From: 1, To: 6},
+			[]int{3, 5},
+			want{false, "", levels(1, 6), []int{3, 5}},
+		},
+		{
+			"resumes after level",
+			BlockRangeInput{From: 1, To: 6, ResumeAfter: 4},
+			nil,
+			want{false, "", levels(5, 6), nil},
+		},
+		{
+			"handles invalid input",
+			BlockRangeInput{From: 6, To: 1},
+			nil,
+			want{true, "invalid input", nil, nil},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests int32
+			server := httptest.NewServer(blockRangeHandlerMock(&requests, tt.failing...))
+			defer server.Close()
+
+			gt, err := New(server.URL, WithLazyConstants())
+			assert.Nil(t, err)
+
+			results, err := gt.BlockRange(context.Background(), tt.input)
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			if tt.want.err {
+				return
+			}
+
+			var got, failed []int
+			for result := range results {
+				got = append(got, result.Level)
+				if result.Err != nil {
+					failed = append(failed, result.Level)
+					assert.Nil(t, result.Block)
+				} else {
+					assert.NotNil(t, result.Block)
+				}
+			}
+
+			assert.Equal(t, tt.want.levels, got)
+			assert.Equal(t, tt.want.failed, failed)
+			assert.Equal(t, int32(len(tt.want.levels)), atomic.LoadInt32(&requests))
+		})
+	}
+}
+
+func Test_BlockRangeFunc(t *testing.T) {
+	var requests int32
+	server := httptest.NewServer(blockRangeHandlerMock(&requests, 4))
+	defer server.Close()
+
+	gt, err := New(server.URL, WithLazyConstants())
+	assert.Nil(t, err)
+
+	t.Run("returns failed levels and last completed level", func(t *testing.T) {
+		var got []int
+		last, err := gt.BlockRangeFunc(context.Background(), BlockRangeInput{From: 1, To: 8, Workers: 3}, func(result BlockRangeResult) error {
+			got = append(got, result.Level)
+			return nil
+		})
+		checkErr(t, true, "failed to fetch 1 level(s): level 4", err)
+		assert.Equal(t, 3, last)
+		assert.Equal(t, levels(1, 8), got)
+
+		var rangeErr *BlockRangeError
+		assert.True(t, errors.As(err, &rangeErr))
+		assert.Len(t, rangeErr.Errors, 1)
+		assert.Contains(t, rangeErr.Errors, 4)
+	})
+
+	t.Run("stops when fn fails", func(t *testing.T) {
+		last, err := gt.BlockRangeFunc(context.Background(), BlockRangeInput{From: 10, To: 30}, func(result BlockRangeResult) error {
+			if result.Level == 12 {
+				return errors.New("disk full")
+			}
+			return nil
+		})
+		checkErr(t, true, "failed to process level 12: disk full", err)
+		assert.Equal(t, 11, last)
+
+		last, err = gt.BlockRangeFunc(context.Background(), BlockRangeInput{From: 10, To: 14, ResumeAfter: last}, func(result BlockRangeResult) error {
+			assert.True(t, result.Level >= 12)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 14, last)
+	})
+
+	t.Run("stops when ctx is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		last, err := gt.BlockRangeFunc(ctx, BlockRangeInput{From: 10, To: 1000}, func(result BlockRangeResult) error {
+			if result.Level == 15 {
+				cancel()
+			}
+			return nil
+		})
+		checkErr(t, true, "failed to fetch block range: context canceled", err)
+		assert.Equal(t, 15, last)
+	})
+}
+
+func levels(from, to int) []int {
+	var l []int
+	for level := from; level <= to; level++ {
+		l = append(l, level)
+	}
+
+	return l
+}
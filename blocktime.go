@@ -0,0 +1,150 @@
+package goMXP
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// TimeMatch selects which block BlockAtTime returns when no block was baked exactly at the time.
+type TimeMatch int
+
+const (
+	// AtOrBefore selects the last block baked at or before the time
+	AtOrBefore TimeMatch = iota
+	// AtOrAfter selects the first block baked at or after the time
+	AtOrAfter
+	// Nearest selects the block baked closest to the time, the earlier one on a tie
+	Nearest
+)
+
+/*
+BlockAtTime gets the block baked at timestamp, or the block selected by match around it. It estimates
+the level from the head and the time between blocks of the network constants, then searches the
+headers of the chain, so it takes a handful of RPC calls instead of one per level.
+
+Parameters:
+
+	timestamp:
+		The time to find the block of.
+
+	match:
+		Which block to return when no block was baked exactly at timestamp: AtOrBefore, AtOrAfter or Nearest.
+*/
+func (t *GoMXP) BlockAtTime(timestamp time.Time, match TimeMatch) (*Block, error) {
+	return t.BlockAtTimeContext(context.Background(), timestamp, match)
+}
+
+// BlockAtTimeContext is BlockAtTime bound to ctx for cancellation and deadlines.
+func (t *GoMXP) BlockAtTimeContext(ctx context.Context, timestamp time.Time, match TimeMatch) (*Block, error) {
+	level, err := t.levelAtTime(ctx, timestamp, match)
+	if err != nil {
+		return &Block{}, errors.Wrapf(err, "could not get block at time '%s'", timestamp.Format(time.RFC3339))
+	}
+
+	return t.BlockContext(ctx, level)
+}
+
+// levelAtTime returns the level of the block selected by match around timestamp.
+func (t *GoMXP) levelAtTime(ctx context.Context, timestamp time.Time, match TimeMatch) (int, error) {
+	if match < AtOrBefore || match > Nearest {
+		return 0, errors.Errorf("invalid time match %d", match)
+	}
+
+	constants, err := t.constants(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	interval := 60 * time.Second
+	if len(constants.TimeBetweenBlocks) > 0 {
+		if seconds, err := strconv.Atoi(constants.TimeBetweenBlocks[0]); err == nil && seconds > 0 {
+			interval = time.Duration(seconds) * time.Second
+		}
+	}
+
+	head, err := t.HeaderContext(ctx, "head")
+	if err != nil {
+		return 0, err
+	}
+
+	if !timestamp.Before(head.Timestamp) {
+		if match == AtOrAfter && timestamp.After(head.Timestamp) {
+			return 0, errors.Errorf("no block at or after time, head '%d' is at '%s'", head.Level, head.Timestamp.Format(time.RFC3339))
+		}
+		return head.Level, nil
+	}
+
+	// Search with lo at or before timestamp and hi after it. lo is unknown (nil) until a block at or
+	// before timestamp is found. Once both are known, guesses interpolate between their timestamps,
+	// falling back to bisection whenever an interpolation did not halve the range.
+	var lo *Header
+	hi := head
+	width, bisect := 0, false
+	for lo == nil || hi.Level-lo.Level > 1 {
+		var guess int
+		if lo == nil {
+			steps := int(hi.Timestamp.Sub(timestamp)/interval) + 1
+			guess = hi.Level - steps
+			if guess < 0 {
+				guess = 0
+			}
+		} else if bisect {
+			guess = lo.Level + (hi.Level-lo.Level)/2
+		} else {
+			elapsed := timestamp.Sub(lo.Timestamp)
+			span := hi.Timestamp.Sub(lo.Timestamp)
+			guess = lo.Level + int(int64(hi.Level-lo.Level)*int64(elapsed)/int64(span))
+			if guess <= lo.Level {
+				guess = lo.Level + 1
+			}
+			if guess >= hi.Level {
+				guess = hi.Level - 1
+			}
+		}
+
+		probe, err := t.HeaderContext(ctx, guess)
+		if err != nil {
+			return 0, err
+		}
+
+		if probe.Timestamp.After(timestamp) {
+			if probe.Level == 0 {
+				if match == AtOrBefore {
+					return 0, errors.Errorf("no block at or before time, genesis is at '%s'", probe.Timestamp.Format(time.RFC3339))
+				}
+				return 0, nil
+			}
+			hi = probe
+			if lo == nil {
+				// the estimate fell short, so widen the next step back
+				interval /= 2
+				if interval < time.Second {
+					interval = time.Second
+				}
+			}
+		} else if probe.Timestamp.Equal(timestamp) {
+			return probe.Level, nil
+		} else {
+			lo = probe
+		}
+
+		if lo != nil {
+			bisect = width > 0 && hi.Level-lo.Level > width/2
+			width = hi.Level - lo.Level
+		}
+	}
+
+	switch match {
+	case AtOrAfter:
+		return hi.Level, nil
+	case Nearest:
+		if hi.Timestamp.Sub(timestamp) < timestamp.Sub(lo.Timestamp) {
+			return hi.Level, nil
+		}
+	}
+
+	return lo.Level, nil
+}
@@ -0,0 +1,123 @@
+package goMXP
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var genesisTime = time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// chainTimes returns the timestamps of a chain of levels blocks, one a minute with every 7th block
+// late by up to ten minutes, so the chain drifts from the time between blocks.
+func chainTimes(levels int) []time.Time {
+	times := []time.Time{genesisTime}
+	for level := 1; level < levels; level++ {
+		delay := time.Minute
+		if level%7 == 0 {
+			delay += time.Duration(level%11) * time.Minute
+		}
+		times = append(times, times[level-1].Add(delay))
+	}
+
+	return times
+}
+
+// blockAtTimeHandlerMock serves the headers and blocks of a chain with times, counting header requests.
+func blockAtTimeHandlerMock(times []time.Time, requests *int32) http.HandlerFunc {
+	path := regexp.MustCompile(`^/chains/main/blocks/([^/]+)(/header)?$`)
+	return func(w http.ResponseWriter, r *http.Request) {
+		match := path.FindStringSubmatch(r.URL.Path)
+		if match == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		level := len(times) - 1
+		if match[1] != "head" {
+			level, _ = strconv.Atoi(match[1])
+		}
+
+		header := Header{Level: level, Timestamp: times[level]}
+		if match[2] != "" {
+			atomic.AddInt32(requests, 1)
+			json.NewEncoder(w).Encode(header)
+			return
+		}
+
+		json.NewEncoder(w).Encode(Block{Hash: "B" + strconv.Itoa(level), Header: header})
+	}
+}
+
+func Test_BlockAtTime(t *testing.T) {
+	times := chainTimes(20000)
+	head := times[len(times)-1]
+
+	var requests int32
+	server := httptest.NewServer(blockAtTimeHandlerMock(times, &requests))
+	defer server.Close()
+
+	gt, err := New(server.URL, WithConstants(Constants{TimeBetweenBlocks: []string{"60", "40"}}))
+	assert.Nil(t, err)
+
+	type input struct {
+		timestamp time.Time
+		match     TimeMatch
+	}
+
+	type want struct {
+		err         bool
+		errContains string
+		level       int
+	}
+
+	cases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{"is successful at a block", input{times[12345], AtOrAfter}, want{false, "", 12345}},
+		{"is successful before", input{times[777].Add(-time.Second), AtOrBefore}, want{false, "", 776}},
+		{"is successful after", input{times[777].Add(-time.Second), AtOrAfter}, want{false, "", 777}},
+		{"is successful nearest", input{times[777].Add(-time.Second), Nearest}, want{false, "", 777}},
+		{"is successful nearest earlier", input{times[776].Add(time.Second), Nearest}, want{false, "", 776}},
+		{"is successful after head", input{head.Add(time.Hour), AtOrBefore}, want{false, "", 19999}},
+		{"is successful at genesis", input{genesisTime, AtOrBefore}, want{false, "", 0}},
+		{"is successful before genesis", input{genesisTime.Add(-time.Hour), Nearest}, want{false, "", 0}},
+		{"handles no block after", input{head.Add(time.Hour), AtOrAfter}, want{true, "no block at or after time", 0}},
+		{"handles no block before", input{genesisTime.Add(-time.Hour), AtOrBefore}, want{true, "no block at or before time", 0}},
+		{"handles invalid match", input{genesisTime, TimeMatch(3)}, want{true, "invalid time match 3", 0}},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			block, err := gt.BlockAtTime(tt.input.timestamp, tt.input.match)
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			assert.Equal(t, tt.want.level, block.Header.Level)
+		})
+	}
+
+	t.Run("takes a handful of requests", func(t *testing.T) {
+		for _, level := range []int{1, 500, 4321, 9999, 15000, 19998} {
+			for _, offset := range []time.Duration{-time.Second, 0, time.Second} {
+				timestamp := times[level].Add(offset)
+				atomic.StoreInt32(&requests, 0)
+
+				block, err := gt.BlockAtTime(timestamp, AtOrBefore)
+				assert.Nil(t, err)
+
+				want := sort.Search(len(times), func(i int) bool { return times[i].After(timestamp) }) - 1
+				assert.Equal(t, want, block.Header.Level)
+				assert.LessOrEqual(t, atomic.LoadInt32(&requests), int32(12))
+			}
+		}
+	})
+}
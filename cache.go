@@ -0,0 +1,201 @@
+package goMXP
+
+import (
+	"container/list"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+/*
+Cache stores the responses of RPC calls by key. Implementations must be safe for concurrent use.
+GoMXP only caches responses to GET requests addressed by block hash, which never change.
+*/
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, value []byte)
+}
+
+/*
+CacheStats counts the lookups GoMXP made in its cache.
+*/
+type CacheStats struct {
+	Hits   uint64
+	Misses uint64
+}
+
+/*
+SetCache sets the cache GoMXP stores responses addressed by block hash in. A nil cache disables caching.
+
+Parameters:
+
+	cache:
+		The cache, e.g. NewLRUCache(1024).
+*/
+func (t *GoMXP) SetCache(cache Cache) {
+	t.update(func(c *config) {
+		c.cache = cache
+	})
+}
+
+/*
+WithCache sets the cache of the GoMXP. See GoMXP.SetCache.
+
+Parameters:
+
+	cache:
+		The cache, e.g. NewLRUCache(1024).
+*/
+func WithCache(cache Cache) Option {
+	return func(o *options) {
+		o.cache = cache
+	}
+}
+
+/*
+CacheStats returns the number of cache hits and misses since GoMXP was created.
+*/
+func (t *GoMXP) CacheStats() CacheStats {
+	return CacheStats{
+		Hits:   atomic.LoadUint64(&t.cacheHits),
+		Misses: atomic.LoadUint64(&t.cacheMisses),
+	}
+}
+
+// cached returns a copy of the cached response to req, if req is cacheable and in the cache, so callers
+// handed the raw response can not change the cache.
+func (t *GoMXP) cached(cfg *config, req *http.Request) ([]byte, bool) {
+	if cfg.cache == nil || !isCacheable(req) {
+		return nil, false
+	}
+
+	byts, ok := cfg.cache.Get(req.URL.String())
+	if !ok {
+		atomic.AddUint64(&t.cacheMisses, 1)
+		return nil, false
+	}
+
+	atomic.AddUint64(&t.cacheHits, 1)
+	return append([]byte(nil), byts...), true
+}
+
+// store caches a copy of the response to req, which is also returned to the caller.
+func (t *GoMXP) store(cfg *config, req *http.Request, byts []byte) {
+	if cfg.cache == nil || !isCacheable(req) {
+		return
+	}
+
+	cfg.cache.Set(req.URL.String(), append([]byte(nil), byts...))
+}
+
+// isCacheable reports whether req is a GET request addressed by block hash.
+func isCacheable(req *http.Request) bool {
+	if req.Method != http.MethodGet {
+		return false
+	}
+
+	segments := strings.Split(req.URL.Path, "/")
+	for i := 1; i < len(segments); i++ {
+		if segments[i-1] == "blocks" {
+			return isBlockHash(segments[i])
+		}
+	}
+
+	return false
+}
+
+/*
+LRUCache is an in-memory Cache that evicts the least recently used response once it holds its size.
+*/
+type LRUCache struct {
+	mu      sync.Mutex
+	size    int
+	entries *list.List
+	index   map[string]*list.Element
+}
+
+type lruEntry struct {
+	key   string
+	value []byte
+}
+
+/*
+NewLRUCache returns an LRUCache holding up to size responses.
+
+Parameters:
+
+	size:
+		The number of responses to hold. Defaults to 1024.
+*/
+func NewLRUCache(size int) *LRUCache {
+	if size <= 0 {
+		size = 1024
+	}
+
+	return &LRUCache{
+		size:    size,
+		entries: list.New(),
+		index:   map[string]*list.Element{},
+	}
+}
+
+/*
+Get returns the response stored for key and marks it as recently used.
+
+Parameters:
+
+	key:
+		The key of the response.
+*/
+func (l *LRUCache) Get(key string) ([]byte, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.index[key]
+	if !ok {
+		return nil, false
+	}
+	l.entries.MoveToFront(element)
+
+	return element.Value.(*lruEntry).value, true
+}
+
+/*
+Set stores the response for key, evicting the least recently used response if the cache is full.
+
+Parameters:
+
+	key:
+		The key of the response.
+
+	value:
+		The response.
+*/
+func (l *LRUCache) Set(key string, value []byte) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.index[key]; ok {
+		element.Value.(*lruEntry).value = value
+		l.entries.MoveToFront(element)
+		return
+	}
+
+	l.index[key] = l.entries.PushFront(&lruEntry{key: key, value: value})
+	if l.entries.Len() > l.size {
+		oldest := l.entries.Back()
+		l.entries.Remove(oldest)
+		delete(l.index, oldest.Value.(*lruEntry).key)
+	}
+}
+
+/*
+Len returns the number of responses in the cache.
+*/
+func (l *LRUCache) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.entries.Len()
+}
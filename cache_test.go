@@ -0,0 +1,172 @@
+package goMXP
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Cache(t *testing.T) {
+	var requests int32
+	var fail int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if atomic.LoadInt32(&fail) == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		switch {
+		case regBalance.MatchString(r.URL.String()):
+			w.Write(readResponse(balance))
+		default:
+			w.Write(readResponse(block))
+		}
+	}))
+	defer server.Close()
+
+	gt, err := New(server.URL, WithLazyConstants(), WithCache(NewLRUCache(10)))
+	assert.Nil(t, err)
+
+	cases := []struct {
+		name         string
+		call         func() error
+		wantRequests int32
+		wantStats    CacheStats
+	}{
+		{
+			"caches block by hash",
+			func() error {
+				_, err := gt.Block(mockBlockHash)
+				return err
+			},
+			1,
+			CacheStats{Hits: 2, Misses: 1},
+		},
+		{
+			"caches balance at block hash",
+			func() error {
+				_, err := gt.Balance(mockBlockHash, mockAddressTz1)
+				return err
+			},
+			1,
+			CacheStats{Hits: 2, Misses: 1},
+		},
+		{
+			"does not cache head",
+			func() error {
+				_, err := gt.Head()
+				return err
+			},
+			3,
+			CacheStats{},
+		},
+		{
+			"does not cache level",
+			func() error {
+				_, err := gt.Block(100)
+				return err
+			},
+			3,
+			CacheStats{},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := gt.CacheStats()
+			atomic.StoreInt32(&requests, 0)
+
+			for i := 0; i < 3; i++ {
+				assert.Nil(t, tt.call())
+			}
+
+			assert.Equal(t, tt.wantRequests, atomic.LoadInt32(&requests))
+			assert.Equal(t, tt.wantStats, CacheStats{
+				Hits:   gt.CacheStats().Hits - stats.Hits,
+				Misses: gt.CacheStats().Misses - stats.Misses,
+			})
+		})
+	}
+
+	t.Run("does not share cached responses", func(t *testing.T) {
+		storage, err := gt.ContractStorage(mockBlockHash, "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg")
+		assert.Nil(t, err)
+		want := string(storage)
+		storage[0] = 'x'
+
+		storage, err = gt.ContractStorage(mockBlockHash, "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg")
+		assert.Nil(t, err)
+		assert.Equal(t, want, string(storage))
+		storage[0] = 'x'
+
+		storage, err = gt.ContractStorage(mockBlockHash, "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg")
+		assert.Nil(t, err)
+		assert.Equal(t, want, string(storage))
+	})
+
+	t.Run("does not cache errors", func(t *testing.T) {
+		atomic.StoreInt32(&fail, 1)
+		_, err := gt.Block("BLJmTCrauYh6wx6ej75yeY6tK9HbTu3xBc1KUU5Rxbw8sQutwn7")
+		checkErr(t, true, "response returned code 500", err)
+
+		atomic.StoreInt32(&fail, 0)
+		atomic.StoreInt32(&requests, 0)
+		_, err = gt.Block("BLJmTCrauYh6wx6ej75yeY6tK9HbTu3xBc1KUU5Rxbw8sQutwn7")
+		assert.Nil(t, err)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+	})
+}
+
+func Test_LRUCache(t *testing.T) {
+	cache := NewLRUCache(2)
+
+	cache.Set("a", []byte("1"))
+	cache.Set("b", []byte("2"))
+
+	v, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("1"), v)
+
+	cache.Set("c", []byte("3"))
+	assert.Equal(t, 2, cache.Len())
+
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+
+	cache.Set("a", []byte("4"))
+	v, ok = cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("4"), v)
+
+	v, ok = cache.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, []byte("3"), v)
+}
+
+func Test_isCacheable(t *testing.T) {
+	cases := []struct {
+		method string
+		path   string
+		want   bool
+	}{
+		{http.MethodGet, "/chains/main/blocks/BLfEWKVudXH15N8nwHZehyLNjRuNLoJavJDjSZ7nq8ggfzbZ18p", true},
+		{http.MethodGet, "/chains/main/blocks/BLfEWKVudXH15N8nwHZehyLNjRuNLoJavJDjSZ7nq8ggfzbZ18p/helpers/baking_rights?cycle=10", true},
+		{http.MethodGet, "/chains/main/blocks/head", false},
+		{http.MethodGet, "/chains/main/blocks/head~2", false},
+		{http.MethodGet, "/chains/main/blocks/839681", false},
+		{http.MethodGet, "/chains/main/blocks", false},
+		{http.MethodGet, "/chains/main/chain_id", false},
+		{http.MethodPost, "/chains/main/blocks/BLfEWKVudXH15N8nwHZehyLNjRuNLoJavJDjSZ7nq8ggfzbZ18p/helpers/forge/operations", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			assert.Equal(t, tt.want, isCacheable(req))
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package goMXP
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strconv"
@@ -82,7 +83,12 @@ Parameters:
 		Modifies the Blocks RPC query by passing optional URL parameters.
 */
 func (t *GoMXP) Blocks(input BlocksInput) ([][]string, error) {
-	resp, err := t.get("/chains/main/blocks", input.contructRPCOptions()...)
+	return t.BlocksContext(context.Background(), input)
+}
+
+// BlocksContext is Blocks bound to ctx for cancellation and deadlines.
+func (t *GoMXP) BlocksContext(ctx context.Context, input BlocksInput) ([][]string, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks", t.chainPath(ctx)), input.contructRPCOptions()...)
 	if err != nil {
 		return [][]string{}, errors.Wrap(err, "failed to get blocks")
 	}
@@ -132,7 +138,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-chains-chain-id-chain-id
 */
 func (t *GoMXP) ChainID() (string, error) {
-	resp, err := t.get("/chains/main/chain_id")
+	return t.ChainIDContext(context.Background())
+}
+
+// ChainIDContext is ChainID bound to ctx for cancellation and deadlines.
+func (t *GoMXP) ChainIDContext(ctx context.Context) (string, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/chain_id", t.chainPath(ctx)))
 	if err != nil {
 		return "", errors.Wrapf(err, "failed to get chain id")
 	}
@@ -156,7 +167,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-chains-chain-id-checkpoint
 */
 func (t *GoMXP) Checkpoint() (Checkpoint, error) {
-	resp, err := t.get("/chains/main/checkpoint")
+	return t.CheckpointContext(context.Background())
+}
+
+// CheckpointContext is Checkpoint bound to ctx for cancellation and deadlines.
+func (t *GoMXP) CheckpointContext(ctx context.Context) (Checkpoint, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/checkpoint", t.chainPath(ctx)))
 	if err != nil {
 		return Checkpoint{}, errors.Wrap(err, "failed to get checkpoint")
 	}
@@ -181,7 +197,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-chains-chain-id-invalid-blocks
 */
 func (t *GoMXP) InvalidBlocks() ([]InvalidBlock, error) {
-	resp, err := t.get("/chains/main/invalid_blocks")
+	return t.InvalidBlocksContext(context.Background())
+}
+
+// InvalidBlocksContext is InvalidBlocks bound to ctx for cancellation and deadlines.
+func (t *GoMXP) InvalidBlocksContext(ctx context.Context) ([]InvalidBlock, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/invalid_blocks", t.chainPath(ctx)))
 	if err != nil {
 		return []InvalidBlock{}, errors.Wrap(err, "failed to get invalid blocks")
 	}
@@ -205,7 +226,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-chains-chain-id-invalid-blocks-block-hash
 */
 func (t *GoMXP) InvalidBlock(blockHash string) (InvalidBlock, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/invalid_blocks/%s", blockHash))
+	return t.InvalidBlockContext(context.Background(), blockHash)
+}
+
+// InvalidBlockContext is InvalidBlock bound to ctx for cancellation and deadlines.
+func (t *GoMXP) InvalidBlockContext(ctx context.Context, blockHash string) (InvalidBlock, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/invalid_blocks/%s", t.chainPath(ctx), blockHash))
 	if err != nil {
 		return InvalidBlock{}, errors.Wrap(err, "failed to get invalid blocks")
 	}
@@ -229,7 +255,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#delete-chains-chain-id-invalid-blocks-block-hash
 */
 func (t *GoMXP) DeleteInvalidBlock(blockHash string) error {
-	_, err := t.delete(fmt.Sprintf("/chains/main/invalid_blocks/%s", blockHash))
+	return t.DeleteInvalidBlockContext(context.Background(), blockHash)
+}
+
+// DeleteInvalidBlockContext is DeleteInvalidBlock bound to ctx for cancellation and deadlines.
+func (t *GoMXP) DeleteInvalidBlockContext(ctx context.Context, blockHash string) error {
+	_, err := t.delete(ctx, fmt.Sprintf("%s/invalid_blocks/%s", t.chainPath(ctx), blockHash))
 	if err != nil {
 		return errors.Wrap(err, "failed to delete invalid blocks")
 	}
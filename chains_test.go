@@ -215,7 +215,7 @@ func Test_Checkpoint(t *testing.T) {
 }
 
 func Test_InvalidBlocks(t *testing.T) {
-	//goldenInvalidBlocks := getResponse(invalidblocks).(*InvalidBlock)
+	goldenInvalidBlocks := getResponse(invalidblocks).([]InvalidBlock)
 
 	type input struct {
 		handler http.Handler
@@ -254,17 +254,17 @@ func Test_InvalidBlocks(t *testing.T) {
 				[]InvalidBlock{},
 			},
 		},
-		// {
-		// 	"is successful",
-		// 	input{
-		// 		gtGoldenHTTPMock(invalidBlocksHandlerMock(mockInvalidBlocksResp, blankHandler)),
-		// 	},
-		// 	want{
-		// 		false,
-		// 		"",
-		// 		&goldenInvalidBlocks,
-		// 	},
-		// },
+		{
+			"is successful",
+			input{
+				gtGoldenHTTPMock(invalidBlocksHandlerMock(readResponse(invalidblocks), blankHandler)),
+			},
+			want{
+				false,
+				"",
+				goldenInvalidBlocks,
+			},
+		},
 	}
 
 	for _, tt := range cases {
@@ -283,7 +283,7 @@ func Test_InvalidBlocks(t *testing.T) {
 }
 
 func Test_InvalidBlock(t *testing.T) {
-	//goldenInvalidBlock := getResponse(invalidblock).(*InvalidBlock)
+	goldenInvalidBlock := getResponse(invalidblock).(InvalidBlock)
 
 	type input struct {
 		handler http.Handler
@@ -322,17 +322,17 @@ func Test_InvalidBlock(t *testing.T) {
 				InvalidBlock{},
 			},
 		},
-		// {
-		// 	"is successful",
-		// 	input{
-		// 		gtGoldenHTTPMock(invalidBlocksHandlerMock(mockInvalidBlockResp, blankHandler)),
-		// 	},
-		// 	want{
-		// 		false,
-		// 		"",
-		// 		&goldenInvalidBlock,
-		// 	},
-		// },
+		{
+			"is successful",
+			input{
+				gtGoldenHTTPMock(invalidBlocksHandlerMock(readResponse(invalidblock), blankHandler)),
+			},
+			want{
+				false,
+				"",
+				goldenInvalidBlock,
+			},
+		},
 	}
 
 	for _, tt := range cases {
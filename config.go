@@ -1,6 +1,7 @@
 package goMXP
 
 import (
+	"context"
 	"encoding/json"
 
 	"github.com/pkg/errors"
@@ -30,7 +31,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-config-network-user-activated-protocol-overrides
 */
 func (t *GoMXP) UserActivatedProtocolOverrides() (UserActivatedProtocolOverrides, error) {
-	resp, err := t.get("/config/network/user_activated_protocol_overrides")
+	return t.UserActivatedProtocolOverridesContext(context.Background())
+}
+
+// UserActivatedProtocolOverridesContext is UserActivatedProtocolOverrides bound to ctx for cancellation and deadlines.
+func (t *GoMXP) UserActivatedProtocolOverridesContext(ctx context.Context) (UserActivatedProtocolOverrides, error) {
+	resp, err := t.get(ctx, "/config/network/user_activated_protocol_overrides")
 	if err != nil {
 		return UserActivatedProtocolOverrides{}, errors.Wrap(err, "failed to get blocks")
 	}
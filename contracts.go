@@ -1,6 +1,7 @@
 package goMXP
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/pkg/errors"
@@ -24,8 +25,13 @@ Parameters:
 		The contract address.
 */
 func (t *GoMXP) ContractStorage(blockhash string, KT1 string) ([]byte, error) {
-	query := fmt.Sprintf("/chains/main/blocks/%s/context/contracts/%s/storage", blockhash, KT1)
-	resp, err := t.get(query)
+	return t.ContractStorageContext(context.Background(), blockhash, KT1)
+}
+
+// ContractStorageContext is ContractStorage bound to ctx for cancellation and deadlines.
+func (t *GoMXP) ContractStorageContext(ctx context.Context, blockhash string, KT1 string) ([]byte, error) {
+	query := fmt.Sprintf("%s/blocks/%s/context/contracts/%s/storage", t.chainPath(ctx), blockhash, KT1)
+	resp, err := t.get(ctx, query)
 	if err != nil {
 		return resp, errors.Wrap(err, "could not get storage '%s'")
 	}
@@ -23,6 +23,18 @@ var (
 	//prefix_edsig     prefix = []byte{9, 245, 205, 134, 18}
 	//prefix_watermark prefix = []byte{3}
 	branchprefix prefix = []byte{1, 52}
+
+	// For (de)constructing block headers
+	operationlisthashprefix prefix = []byte{29, 159, 109}
+	contextprefix           prefix = []byte{79, 199}
+	noncehashprefix         prefix = []byte{69, 220, 169}
+	sigprefix               prefix = []byte{4, 130, 43}
+	edsigprefix             prefix = []byte{9, 245, 205, 134, 18}
+	spsigprefix             prefix = []byte{13, 115, 101, 19, 63}
+	p2sigprefix             prefix = []byte{54, 240, 44, 52}
+
+	// For hashing operations
+	operationprefix prefix = []byte{5, 116}
 )
 
 //b58cencode encodes a byte array into base58 with prefix
@@ -1,6 +1,7 @@
 package goMXP
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -164,7 +165,12 @@ Parameters:
 		The tz(1-3) address of the delegate.
 */
 func (t *GoMXP) DelegatedContracts(blockhash, delegate string) ([]*string, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates/%s/delegated_contracts", blockhash, delegate))
+	return t.DelegatedContractsContext(context.Background(), blockhash, delegate)
+}
+
+// DelegatedContractsContext is DelegatedContracts bound to ctx for cancellation and deadlines.
+func (t *GoMXP) DelegatedContractsContext(ctx context.Context, blockhash, delegate string) ([]*string, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/context/delegates/%s/delegated_contracts", t.chainPath(ctx), blockhash, delegate))
 	if err != nil {
 		return []*string{}, errors.Wrapf(err, "could not get delegations for '%s'", delegate)
 	}
@@ -195,12 +201,17 @@ Parameters:
 		The tz(1-3) address of the delegate.
 */
 func (t *GoMXP) DelegatedContractsAtCycle(cycle int, delegate string) ([]*string, error) {
-	snapshot, err := t.Cycle(cycle)
+	return t.DelegatedContractsAtCycleContext(context.Background(), cycle, delegate)
+}
+
+// DelegatedContractsAtCycleContext is DelegatedContractsAtCycle bound to ctx for cancellation and deadlines.
+func (t *GoMXP) DelegatedContractsAtCycleContext(ctx context.Context, cycle int, delegate string) ([]*string, error) {
+	snapshot, err := t.CycleContext(ctx, cycle)
 	if err != nil {
 		return []*string{}, errors.Wrapf(err, "could not get delegations for '%s' at cycle '%d'", delegate, cycle)
 	}
 
-	delegations, err := t.DelegatedContracts(snapshot.BlockHash, delegate)
+	delegations, err := t.DelegatedContractsContext(ctx, snapshot.BlockHash, delegate)
 	if err != nil {
 		return []*string{}, errors.Wrapf(err, "could not get delegations at cycle '%d'", cycle)
 	}
@@ -225,14 +236,24 @@ Parameters:
 		The tz(1-3) address of the delegate.
 */
 func (t *GoMXP) FrozenBalance(cycle int, delegate string) (FrozenBalance, error) {
-	level := (cycle+1)*(t.networkConstants.BlocksPerCycle) + 1
+	return t.FrozenBalanceContext(context.Background(), cycle, delegate)
+}
 
-	head, err := t.Block(level)
+// FrozenBalanceContext is FrozenBalance bound to ctx for cancellation and deadlines.
+func (t *GoMXP) FrozenBalanceContext(ctx context.Context, cycle int, delegate string) (FrozenBalance, error) {
+	constants, err := t.constants(ctx)
 	if err != nil {
 		return FrozenBalance{}, errors.Wrapf(err, "failed to get frozen balance at cycle '%d' for delegate '%s'", cycle, delegate)
 	}
 
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/raw/json/contracts/index/%s/frozen_balance/%d/", head.Hash, delegate, cycle))
+	level := (cycle+1)*(constants.BlocksPerCycle) + 1
+
+	head, err := t.BlockContext(ctx, level)
+	if err != nil {
+		return FrozenBalance{}, errors.Wrapf(err, "failed to get frozen balance at cycle '%d' for delegate '%s'", cycle, delegate)
+	}
+
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/context/raw/json/contracts/index/%s/frozen_balance/%d/", t.chainPath(ctx), head.Hash, delegate, cycle))
 	if err != nil {
 		return FrozenBalance{}, errors.Wrapf(err, "failed to get frozen balance at cycle '%d' for delegate '%s'", cycle, delegate)
 	}
@@ -264,7 +285,12 @@ Parameters:
 		The tz(1-3) address of the delegate.
 */
 func (t *GoMXP) Delegate(blockhash, delegate string) (Delegate, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates/%s", blockhash, delegate))
+	return t.DelegateContext(context.Background(), blockhash, delegate)
+}
+
+// DelegateContext is Delegate bound to ctx for cancellation and deadlines.
+func (t *GoMXP) DelegateContext(ctx context.Context, blockhash, delegate string) (Delegate, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/context/delegates/%s", t.chainPath(ctx), blockhash, delegate))
 	if err != nil {
 		return Delegate{}, errors.Wrapf(err, "could not get delegate '%s'", delegate)
 	}
@@ -298,7 +324,12 @@ Parameters:
 		The tz(1-3) address of the delegate.
 */
 func (t *GoMXP) StakingBalance(blockhash, delegate string) (*big.Int, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates/%s/staking_balance", blockhash, delegate))
+	return t.StakingBalanceContext(context.Background(), blockhash, delegate)
+}
+
+// StakingBalanceContext is StakingBalance bound to ctx for cancellation and deadlines.
+func (t *GoMXP) StakingBalanceContext(ctx context.Context, blockhash, delegate string) (*big.Int, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/context/delegates/%s/staking_balance", t.chainPath(ctx), blockhash, delegate))
 	if err != nil {
 		return big.NewInt(0), errors.Wrapf(err, "could not get staking balance for '%s'", delegate)
 	}
@@ -331,12 +362,17 @@ Parameters:
 		The tz(1-3) address of the delegate.
 */
 func (t *GoMXP) StakingBalanceAtCycle(cycle int, delegate string) (*big.Int, error) {
-	snapshot, err := t.Cycle(cycle)
+	return t.StakingBalanceAtCycleContext(context.Background(), cycle, delegate)
+}
+
+// StakingBalanceAtCycleContext is StakingBalanceAtCycle bound to ctx for cancellation and deadlines.
+func (t *GoMXP) StakingBalanceAtCycleContext(ctx context.Context, cycle int, delegate string) (*big.Int, error) {
+	snapshot, err := t.CycleContext(ctx, cycle)
 	if err != nil {
 		return big.NewInt(0), errors.Wrapf(err, "could not get staking balance for '%s' at cycle '%d'", delegate, cycle)
 	}
 
-	balance, err := t.StakingBalance(snapshot.BlockHash, delegate)
+	balance, err := t.StakingBalanceContext(ctx, snapshot.BlockHash, delegate)
 	if err != nil {
 		return big.NewInt(0), errors.Wrapf(err, "could not get staking balance for '%s' at cycle '%d'", delegate, cycle)
 	}
@@ -369,12 +405,17 @@ Parameters:
 
 */
 func (t *GoMXP) BakingRights(input BakingRightsInput) (*BakingRights, error) {
+	return t.BakingRightsContext(context.Background(), input)
+}
+
+// BakingRightsContext is BakingRights bound to ctx for cancellation and deadlines.
+func (t *GoMXP) BakingRightsContext(ctx context.Context, input BakingRightsInput) (*BakingRights, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return &BakingRights{}, errors.Wrap(err, "invalid input")
 	}
 
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/helpers/baking_rights", *input.BlockHash), input.contructRPCOptions()...)
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/helpers/baking_rights", t.chainPath(ctx), *input.BlockHash), input.contructRPCOptions()...)
 	if err != nil {
 		return &BakingRights{}, errors.Wrapf(err, "could not get baking rights")
 	}
@@ -444,12 +485,17 @@ Parameters:
 
 */
 func (t *GoMXP) EndorsingRights(input EndorsingRightsInput) (*EndorsingRights, error) {
+	return t.EndorsingRightsContext(context.Background(), input)
+}
+
+// EndorsingRightsContext is EndorsingRights bound to ctx for cancellation and deadlines.
+func (t *GoMXP) EndorsingRightsContext(ctx context.Context, input EndorsingRightsInput) (*EndorsingRights, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return &EndorsingRights{}, errors.Wrap(err, "invalid input")
 	}
 
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/helpers/endorsing_rights", *input.BlockHash), input.contructRPCOptions()...)
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/helpers/endorsing_rights", t.chainPath(ctx), *input.BlockHash), input.contructRPCOptions()...)
 	if err != nil {
 		return &EndorsingRights{}, errors.Wrap(err, "could not get endorsing rights")
 	}
@@ -507,12 +553,17 @@ Parameters:
 		The tz(1-3) address of the delegate.
 */
 func (t *GoMXP) Delegates(input DelegatesInput) ([]*string, error) {
+	return t.DelegatesContext(context.Background(), input)
+}
+
+// DelegatesContext is Delegates bound to ctx for cancellation and deadlines.
+func (t *GoMXP) DelegatesContext(ctx context.Context, input DelegatesInput) ([]*string, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return []*string{}, errors.Wrap(err, "invalid input")
 	}
 
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/delegates", *input.BlockHash), input.contructRPCOptions()...)
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/context/delegates", t.chainPath(ctx), *input.BlockHash), input.contructRPCOptions()...)
 	if err != nil {
 		return []*string{}, errors.Wrap(err, "could not get delegates")
 	}
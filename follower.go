@@ -0,0 +1,288 @@
+package goMXP
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+/*
+BlockEventType is the kind of a BlockEvent.
+*/
+type BlockEventType string
+
+const (
+	// Applied is sent when a block becomes part of the followed chain.
+	Applied BlockEventType = "applied"
+
+	// Reverted is sent when an applied block is removed from the followed chain by a reorganization.
+	Reverted BlockEventType = "reverted"
+)
+
+/*
+BlockEvent is a change to the chain followed by a Follower.
+*/
+type BlockEvent struct {
+	Type  BlockEventType
+	Block *Block
+}
+
+/*
+FollowerInput is the input for the goMXP.NewFollower function.
+
+Function:
+	func (t *GoMXP) NewFollower(input FollowerInput) (*Follower, error) {}
+*/
+type FollowerInput struct {
+	// How many blocks must be built on a block before it is applied. 0 applies every head at once.
+	Confirmations int `validate:"min=0"`
+
+	// How many blocks beyond Confirmations are remembered to detect reorganizations. A reorganization
+	// deeper than Confirmations+History can not be resolved: the remembered blocks are reverted, the
+	// follower continues from the new branch and the reorganization is reported as an error. Defaults to 64.
+	History int `validate:"min=0"`
+
+	// Fetch the full block of every head instead of only its hash and header.
+	FullBlock bool
+
+	// How long to wait before reconnecting after the heads stream fails. Defaults to 1s.
+	ReconnectDelay time.Duration
+
+	// Optional hook called with every error the follower recovers from (e.g. a failed stream or a
+	// predecessor that could not be fetched).
+	OnError func(err error)
+}
+
+/*
+Follower follows the head of the chain, links every new head to the blocks it has already seen by
+Header.Predecessor and detects reorganizations. Subscribers receive an Applied event for every block
+of the chain in level order once it has input.Confirmations blocks built on it, and a Reverted event,
+newest first, for every applied block a reorganization removes.
+
+Blocks of the heads stream only have their Hash and Header filled unless input.FullBlock is set.
+Blocks fetched to fill gaps in the stream are always full.
+*/
+type Follower struct {
+	gt    *GoMXP
+	input FollowerInput
+
+	mu          sync.Mutex
+	subscribers []*subscriber
+
+	// chain holds the most recent blocks of the followed chain, by ascending level.
+	chain     []*Block
+	delivered int
+}
+
+type subscriber struct {
+	events chan BlockEvent
+	done   chan struct{}
+	once   sync.Once
+}
+
+/*
+NewFollower returns a Follower of the chain of the GoMXP (see SetChain). It does nothing until Run is called.
+
+Parameters:
+
+	input:
+		Modifies the Follower.
+*/
+func (t *GoMXP) NewFollower(input FollowerInput) (*Follower, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	if input.History == 0 {
+		input.History = 64
+	}
+
+	return &Follower{gt: t, input: input, delivered: -1}, nil
+}
+
+/*
+Subscribe returns a channel receiving the events of the follower and a function that unsubscribes
+it. The follower waits for every subscriber to receive an event before sending the next one, so a
+subscriber that stops reading must unsubscribe. The channel is closed on unsubscribe or once Run returns.
+
+Parameters:
+
+	buffer:
+		The size of the buffer of the channel.
+*/
+func (f *Follower) Subscribe(buffer int) (<-chan BlockEvent, func()) {
+	s := &subscriber{
+		events: make(chan BlockEvent, buffer),
+		done:   make(chan struct{}),
+	}
+
+	f.mu.Lock()
+	f.subscribers = append(f.subscribers, s)
+	f.mu.Unlock()
+
+	return s.events, func() {
+		s.once.Do(func() { close(s.done) })
+
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for i, sub := range f.subscribers {
+			if sub == s {
+				f.subscribers = append(f.subscribers[:i:i], f.subscribers[i+1:]...)
+				close(s.events)
+				return
+			}
+		}
+	}
+}
+
+/*
+Run follows the chain until ctx is done and then closes the channels of all subscribers. It returns ctx.Err().
+
+Parameters:
+
+	ctx:
+		Cancel ctx to stop following the chain.
+*/
+func (f *Follower) Run(ctx context.Context) error {
+	defer f.closeSubscribers()
+
+	heads, errs := f.gt.MonitorHeads(ctx, MonitorHeadsInput{
+		FullBlock:      f.input.FullBlock,
+		ReconnectDelay: f.input.ReconnectDelay,
+	})
+
+	for {
+		select {
+		case head, ok := <-heads:
+			if !ok {
+				return ctx.Err()
+			}
+
+			if err := f.handle(ctx, head); err != nil && ctx.Err() == nil {
+				f.report(err)
+			}
+		case err, ok := <-errs:
+			if !ok {
+				errs = nil
+				continue
+			}
+			f.report(err)
+		}
+	}
+}
+
+// handle links head to the followed chain, reverting the blocks it replaces and applying the blocks that became final.
+func (f *Follower) handle(ctx context.Context, head *Block) error {
+	if f.index(head.Hash) >= 0 {
+		return nil
+	}
+
+	// Walk back from head until a block the follower has seen, fetching the blocks the stream skipped.
+	branch := []*Block{head}
+	ancestor := -1
+	var deep error
+	for len(f.chain) > 0 {
+		tip := branch[len(branch)-1]
+		if ancestor = f.index(tip.Header.Predecessor); ancestor >= 0 {
+			break
+		}
+
+		if tip.Header.Level <= f.chain[0].Header.Level {
+			// Nothing remembered links to the branch, so revert every remembered block and follow the branch from tip.
+			deep = errors.Errorf("could not link block '%s': reorganization deeper than %d blocks", head.Hash, len(f.chain))
+			break
+		}
+
+		predecessor, err := f.gt.BlockContext(ctx, tip.Header.Predecessor)
+		if err != nil {
+			return errors.Wrapf(err, "could not get predecessor of block '%s'", tip.Hash)
+		}
+		branch = append(branch, predecessor)
+	}
+
+	reverted := f.chain[ancestor+1:]
+	for i := len(reverted) - 1; i >= 0; i-- {
+		if reverted[i].Header.Level > f.delivered {
+			continue
+		}
+
+		if err := f.publish(ctx, BlockEvent{Type: Reverted, Block: reverted[i]}); err != nil {
+			return err
+		}
+		f.delivered = reverted[i].Header.Level - 1
+	}
+
+	if oldest := branch[len(branch)-1].Header.Level; f.delivered >= oldest {
+		f.delivered = oldest - 1
+	}
+
+	chain := append([]*Block{}, f.chain[:ancestor+1]...)
+	for i := len(branch) - 1; i >= 0; i-- {
+		chain = append(chain, branch[i])
+	}
+	f.chain = chain
+
+	for _, block := range f.chain {
+		if block.Header.Level <= f.delivered || head.Header.Level-block.Header.Level < f.input.Confirmations {
+			continue
+		}
+
+		if err := f.publish(ctx, BlockEvent{Type: Applied, Block: block}); err != nil {
+			return err
+		}
+		f.delivered = block.Header.Level
+	}
+
+	if keep := f.input.Confirmations + f.input.History + 1; len(f.chain) > keep {
+		f.chain = f.chain[len(f.chain)-keep:]
+	}
+
+	return deep
+}
+
+// index returns the position of the block with hash in the followed chain, or -1.
+func (f *Follower) index(hash string) int {
+	for i := len(f.chain) - 1; i >= 0; i-- {
+		if f.chain[i].Hash == hash {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (f *Follower) publish(ctx context.Context, event BlockEvent) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, s := range f.subscribers {
+		select {
+		case s.events <- event:
+		case <-s.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+func (f *Follower) report(err error) {
+	if f.input.OnError != nil {
+		f.input.OnError(err)
+	}
+}
+
+func (f *Follower) closeSubscribers() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, s := range f.subscribers {
+		close(s.events)
+	}
+	f.subscribers = nil
+}
@@ -0,0 +1,173 @@
+package goMXP
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mockLink struct {
+	hash        string
+	predecessor string
+	level       int
+}
+
+// followerHandlerMock streams heads on a single connection and serves the blocks in blocks by hash.
+func followerHandlerMock(heads []mockLink, blocks []mockLink) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regMonitorHeads.MatchString(r.URL.String()) {
+			for _, head := range heads {
+				fmt.Fprintf(w, `{"hash":"%s","level":%d,"predecessor":"%s"}`+"\n", head.hash, head.level, head.predecessor)
+				w.(http.Flusher).Flush()
+			}
+			<-r.Context().Done()
+			return
+		}
+
+		for _, block := range blocks {
+			if strings.HasSuffix(r.URL.Path, "/blocks/"+block.hash) {
+				fmt.Fprintf(w, `{"hash":"%s","header":{"level":%d,"predecessor":"%s"}}`, block.hash, block.level, block.predecessor)
+				return
+			}
+		}
+
+		w.WriteHeader(http.StatusNotFound)
+	})
+}
+
+func Test_Follower(t *testing.T) {
+	heads := []mockLink{
+		{"BLa", "BL0", 1},
+		{"BLb", "BLa", 2},
+		{"BLc", "BLb", 3},
+		{"BLc2", "BLb", 3},
+		{"BLe", "BLd", 5},
+	}
+	blocks := []mockLink{
+		{"BLd", "BLc2", 4},
+	}
+
+	cases := []struct {
+		name  string
+		input FollowerInput
+		want  []string
+	}{
+		{
+			"delivers heads and reorganizations",
+			FollowerInput{},
+			[]string{"applied BLa", "applied BLb", "applied BLc", "reverted BLc", "applied BLc2", "applied BLd", "applied BLe"},
+		},
+		{
+			"delivers only confirmed blocks",
+			FollowerInput{Confirmations: 1},
+			[]string{"applied BLa", "applied BLb", "applied BLc2", "applied BLd"},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(followerHandlerMock(heads, blocks))
+			defer server.Close()
+
+			gt, err := New(server.URL, WithLazyConstants())
+			assert.Nil(t, err)
+
+			follower, err := gt.NewFollower(tt.input)
+			assert.Nil(t, err)
+
+			events, _ := follower.Subscribe(0)
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+			go func() { done <- follower.Run(ctx) }()
+
+			var got []string
+			for len(got) < len(tt.want) {
+				select {
+				case event := <-events:
+					got = append(got, fmt.Sprintf("%s %s", event.Type, event.Block.Hash))
+				case <-time.After(5 * time.Second):
+					t.Fatalf("timed out after events %v", got)
+				}
+			}
+			assert.Equal(t, tt.want, got)
+
+			cancel()
+			assert.Equal(t, context.Canceled, <-done)
+			_, ok := <-events
+			assert.False(t, ok)
+		})
+	}
+
+	t.Run("recovers from reorganizations deeper than its history", func(t *testing.T) {
+		server := httptest.NewServer(followerHandlerMock([]mockLink{
+			{"BLa", "BL0", 1},
+			{"BLb", "BLa", 2},
+			{"BLc", "BLb", 3},
+			{"BLx", "BLy", 3},
+			{"BLz", "BLx", 4},
+		}, []mockLink{
+			{"BLy", "BLa", 2},
+		}))
+		defer server.Close()
+
+		gt, err := New(server.URL, WithLazyConstants())
+		assert.Nil(t, err)
+
+		errs := make(chan error, 1)
+		follower, err := gt.NewFollower(FollowerInput{History: 1, OnError: func(err error) { errs <- err }})
+		assert.Nil(t, err)
+
+		events, _ := follower.Subscribe(0)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go follower.Run(ctx)
+
+		want := []string{"applied BLa", "applied BLb", "applied BLc", "reverted BLc", "reverted BLb", "applied BLy", "applied BLx", "applied BLz"}
+		var got []string
+		for len(got) < len(want) {
+			select {
+			case event := <-events:
+				got = append(got, fmt.Sprintf("%s %s", event.Type, event.Block.Hash))
+			case <-time.After(5 * time.Second):
+				t.Fatalf("timed out after events %v", got)
+			}
+		}
+		assert.Equal(t, want, got)
+
+		select {
+		case err := <-errs:
+			checkErr(t, true, "could not link block 'BLx': reorganization deeper than 2 blocks", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out")
+		}
+	})
+
+	t.Run("unsubscribes", func(t *testing.T) {
+		gt, err := New("http://localhost:0", WithLazyConstants())
+		assert.Nil(t, err)
+
+		follower, err := gt.NewFollower(FollowerInput{})
+		assert.Nil(t, err)
+
+		events, unsubscribe := follower.Subscribe(1)
+		unsubscribe()
+		unsubscribe()
+		_, ok := <-events
+		assert.False(t, ok)
+		assert.Len(t, follower.subscribers, 0)
+	})
+
+	t.Run("handles invalid input", func(t *testing.T) {
+		gt, err := New("http://localhost:0", WithLazyConstants())
+		assert.Nil(t, err)
+
+		_, err = gt.NewFollower(FollowerInput{Confirmations: -1})
+		checkErr(t, true, "invalid input", err)
+	})
+}
@@ -0,0 +1,899 @@
+/*
+Package goMXPtest provides a fake MXP node for testing code that uses goMXP without a real node.
+
+The node serves the subset of the RPC goMXP calls from an httptest.Server: heads, blocks, headers,
+balances, counters, constants, baking and endorsing rights, forge, parse, preapply and injection.
+It keeps a small in-memory state of accounts. Injected operations are validated against it, wait in
+the mempool and are applied by Bake, which adds a block on top of the head.
+
+Example:
+	node := goMXPtest.NewNode(goMXPtest.WithAccount("tz1...", 1000000, 0))
+	defer node.Close()
+
+	gt, err := goMXP.New(node.URL)
+*/
+package goMXPtest
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/btcsuite/btcutil/base58"
+	goMXP "github.com/goat-systems/go-MXP/v2"
+	"golang.org/x/crypto/blake2b"
+)
+
+const (
+	// ChainID is the default chain id of a Node.
+	ChainID = "NetXdQprcVkpaWU"
+
+	// Protocol is the protocol hash of the blocks of a Node.
+	Protocol = "PsCARTHAGazKbHtnKfLzQg3kms52kSRpgnDY982a9oYsSXRLQEb"
+)
+
+var blockPrefix = []byte{1, 52}
+
+/*
+Account is the state of an account of a Node.
+*/
+type Account struct {
+	Balance *big.Int
+	Counter int
+}
+
+/*
+Option configures a Node. See NewNode.
+*/
+type Option func(n *Node)
+
+/*
+WithChainID sets the chain id of the Node. Defaults to ChainID.
+
+Parameters:
+
+	chainID:
+		The chain id.
+*/
+func WithChainID(chainID string) Option {
+	return func(n *Node) {
+		n.chainID = chainID
+	}
+}
+
+/*
+WithConstants sets the constants the Node serves. Defaults to the mainnet constants.
+
+Parameters:
+
+	constants:
+		The constants.
+*/
+func WithConstants(constants goMXP.Constants) Option {
+	return func(n *Node) {
+		n.constants = constants
+	}
+}
+
+/*
+WithAccount adds an account to the genesis state of the Node.
+
+Parameters:
+
+	address:
+		The address of the account.
+
+	balance:
+		The balance of the account in mutez.
+
+	counter:
+		The counter of the account.
+*/
+func WithAccount(address string, balance int64, counter int) Option {
+	return func(n *Node) {
+		n.accounts[address] = Account{Balance: big.NewInt(balance), Counter: counter}
+	}
+}
+
+/*
+WithDelegates sets the delegates the Node hands out baking and endorsing rights to, in turn.
+
+Parameters:
+
+	delegates:
+		The public key hashes of the delegates.
+*/
+func WithDelegates(delegates ...string) Option {
+	return func(n *Node) {
+		n.delegates = delegates
+	}
+}
+
+/*
+Node is a fake MXP node served by an httptest.Server. Point goMXP.New at Node.URL. It is safe for
+concurrent use.
+*/
+type Node struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	chainID   string
+	constants goMXP.Constants
+	delegates []string
+	blocks    []snapshot
+	accounts  map[string]Account
+	mempool   []goMXP.Operations
+	pending   map[string]int
+	forged    map[string]goMXP.Operations
+	failures  []*failure
+	baked     chan struct{}
+	closed    chan struct{}
+}
+
+// snapshot is a block and the state of the accounts after it.
+type snapshot struct {
+	block    *goMXP.Block
+	accounts map[string]Account
+}
+
+type failure struct {
+	pattern   *regexp.Regexp
+	remaining int
+	forever   bool
+	status    int
+	errs      []goMXP.RPCError
+}
+
+/*
+NewNode starts a Node whose chain holds a genesis block at level 0. Close the Node when done.
+
+Parameters:
+
+	opts:
+		Options that configure the Node (e.g. WithAccount).
+*/
+func NewNode(opts ...Option) *Node {
+	n := &Node{
+		chainID:   ChainID,
+		constants: defaultConstants(),
+		accounts:  map[string]Account{},
+		pending:   map[string]int{},
+		forged:    map[string]goMXP.Operations{},
+		baked:     make(chan struct{}),
+		closed:    make(chan struct{}),
+	}
+
+	for _, opt := range opts {
+		opt(n)
+	}
+
+	genesis := &goMXP.Block{
+		Protocol: Protocol,
+		ChainID:  n.chainID,
+		Header: goMXP.Header{
+			Timestamp:      time.Now().UTC().Truncate(time.Second),
+			ValidationPass: 4,
+			Fitness:        []string{"01", "0000000000000000"},
+		},
+		Operations: [][]goMXP.Operations{{}, {}, {}, {}},
+	}
+	genesis.Hash = blockHash(genesis)
+	n.blocks = []snapshot{{block: genesis, accounts: copyAccounts(n.accounts)}}
+
+	n.Server = httptest.NewServer(http.HandlerFunc(n.serveHTTP))
+
+	return n
+}
+
+/*
+Close ends the open head streams of the Node and shuts it down.
+*/
+func (n *Node) Close() {
+	close(n.closed)
+	n.Server.Close()
+}
+
+/*
+Head returns the head of the chain of the Node.
+*/
+func (n *Node) Head() *goMXP.Block {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.blocks[len(n.blocks)-1].block
+}
+
+/*
+Account returns the state of address at the head of the chain.
+
+Parameters:
+
+	address:
+		The address of the account.
+*/
+func (n *Node) Account(address string) Account {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return n.account(address)
+}
+
+/*
+SetAccount overrides the state of address. The change is visible at the head once the next block is baked.
+
+Parameters:
+
+	address:
+		The address of the account.
+
+	account:
+		The new state of the account.
+*/
+func (n *Node) SetAccount(address string, account Account) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.accounts[address] = Account{Balance: new(big.Int).Set(account.Balance), Counter: account.Counter}
+}
+
+/*
+Pending returns the operations that were injected since the last block.
+*/
+func (n *Node) Pending() []goMXP.Operations {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	return append([]goMXP.Operations{}, n.mempool...)
+}
+
+/*
+Bake applies the pending operations and adds a block holding them on top of the head, which is
+sent to the open head streams. It returns the new head.
+*/
+func (n *Node) Bake() *goMXP.Block {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	head := n.blocks[len(n.blocks)-1].block
+
+	applied := []goMXP.Operations{}
+	for _, op := range n.mempool {
+		n.apply(op)
+		applied = append(applied, withStatus(op, "applied"))
+	}
+
+	block := &goMXP.Block{
+		Protocol: Protocol,
+		ChainID:  n.chainID,
+		Header: goMXP.Header{
+			Level:          head.Header.Level + 1,
+			Proto:          1,
+			Predecessor:    head.Hash,
+			Timestamp:      head.Header.Timestamp.Add(time.Minute),
+			ValidationPass: 4,
+			Fitness:        []string{"01", fmt.Sprintf("%016x", head.Header.Level+1)},
+		},
+		Operations: [][]goMXP.Operations{{}, {}, {}, applied},
+	}
+	block.Hash = blockHash(block)
+
+	n.blocks = append(n.blocks, snapshot{block: block, accounts: copyAccounts(n.accounts)})
+	n.mempool = nil
+	n.pending = map[string]int{}
+
+	close(n.baked)
+	n.baked = make(chan struct{})
+
+	return block
+}
+
+/*
+FailRequests makes the requests whose path matches pattern fail with status and errs, before any
+other processing. The failure applies to the next times requests, or to every request if times is 0.
+It panics if pattern is not a valid regular expression.
+
+Parameters:
+
+	pattern:
+		A regular expression matched against the path of requests (e.g. /injection/operation).
+
+	times:
+		How many requests fail, or 0 for all of them.
+
+	status:
+		The HTTP status of the failure.
+
+	errs:
+		The errors in the body of the failure.
+*/
+func (n *Node) FailRequests(pattern string, times, status int, errs ...goMXP.RPCError) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.failures = append(n.failures, &failure{
+		pattern:   regexp.MustCompile(pattern),
+		remaining: times,
+		forever:   times <= 0,
+		status:    status,
+		errs:      errs,
+	})
+}
+
+/*
+ClearFailures removes the failures added by FailRequests.
+*/
+func (n *Node) ClearFailures() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.failures = nil
+}
+
+// route is an RPC of the node. handle is called with the submatches of pattern and the state lock held,
+// except for streams.
+type route struct {
+	method  string
+	pattern *regexp.Regexp
+	stream  bool
+	handle  func(n *Node, w http.ResponseWriter, r *http.Request, match []string)
+}
+
+var routes = []route{
+	{http.MethodGet, regexp.MustCompile(`^/monitor/bootstrapped$`), false, (*Node).bootstrapped},
+	{http.MethodGet, regexp.MustCompile(`^/monitor/heads/([^/]+)$`), true, (*Node).monitorHeads},
+	{http.MethodGet, regexp.MustCompile(`^/chains/([^/]+)/chain_id$`), false, (*Node).chainIDHandler},
+	{http.MethodGet, regexp.MustCompile(`^/chains/([^/]+)/blocks/([^/]+)$`), false, (*Node).blockHandler},
+	{http.MethodGet, regexp.MustCompile(`^/chains/([^/]+)/blocks/([^/]+)/header$`), false, (*Node).header},
+	{http.MethodGet, regexp.MustCompile(`^/chains/([^/]+)/blocks/([^/]+)/context/contracts/([^/]+)/balance$`), false, (*Node).balance},
+	{http.MethodGet, regexp.MustCompile(`^/chains/([^/]+)/blocks/([^/]+)/context/contracts/([^/]+)/counter$`), false, (*Node).counter},
+	{http.MethodGet, regexp.MustCompile(`^/chains/([^/]+)/blocks/([^/]+)/context/constants$`), false, (*Node).constantsHandler},
+	{http.MethodGet, regexp.MustCompile(`^/chains/([^/]+)/blocks/([^/]+)/helpers/baking_rights$`), false, (*Node).bakingRights},
+	{http.MethodGet, regexp.MustCompile(`^/chains/([^/]+)/blocks/([^/]+)/helpers/endorsing_rights$`), false, (*Node).endorsingRights},
+	{http.MethodPost, regexp.MustCompile(`^/chains/([^/]+)/blocks/([^/]+)/helpers/forge/operations$`), false, (*Node).forge},
+	{http.MethodPost, regexp.MustCompile(`^/chains/([^/]+)/blocks/([^/]+)/helpers/parse/operations$`), false, (*Node).parse},
+	{http.MethodPost, regexp.MustCompile(`^/chains/([^/]+)/blocks/([^/]+)/helpers/preapply/operations$`), false, (*Node).preapply},
+	{http.MethodPost, regexp.MustCompile(`^/injection/operation$`), false, (*Node).inject},
+}
+
+func (n *Node) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	n.mu.Lock()
+	for _, f := range n.failures {
+		if (f.forever || f.remaining > 0) && f.pattern.MatchString(r.URL.Path) {
+			f.remaining--
+			n.mu.Unlock()
+			writeErrors(w, f.status, f.errs...)
+			return
+		}
+	}
+	n.mu.Unlock()
+
+	for _, rt := range routes {
+		match := rt.pattern.FindStringSubmatch(r.URL.Path)
+		if match == nil || rt.method != r.Method {
+			continue
+		}
+
+		if strings.HasPrefix(r.URL.Path, "/chains/") && match[1] != "main" && match[1] != n.chainID {
+			http.NotFound(w, r)
+			return
+		}
+
+		if rt.stream {
+			rt.handle(n, w, r, match)
+			return
+		}
+
+		n.mu.Lock()
+		defer n.mu.Unlock()
+		rt.handle(n, w, r, match)
+		return
+	}
+
+	http.NotFound(w, r)
+}
+
+func (n *Node) bootstrapped(w http.ResponseWriter, r *http.Request, match []string) {
+	head := n.blocks[len(n.blocks)-1].block
+	writeJSON(w, goMXP.Bootstrap{Block: head.Hash, Timestamp: head.Header.Timestamp})
+}
+
+func (n *Node) monitorHeads(w http.ResponseWriter, r *http.Request, match []string) {
+	if match[1] != "main" && match[1] != n.chainID {
+		http.NotFound(w, r)
+		return
+	}
+
+	flusher, _ := w.(http.Flusher)
+	w.Header().Set("Content-Type", "application/json")
+
+	next := -1
+	for {
+		n.mu.Lock()
+		if next < 0 {
+			next = len(n.blocks) - 1
+		}
+		blocks := n.blocks[next:]
+		next = len(n.blocks)
+		baked := n.baked
+		n.mu.Unlock()
+
+		for _, s := range blocks {
+			byts, _ := json.Marshal(struct {
+				Hash string `json:"hash"`
+				goMXP.Header
+			}{s.block.Hash, s.block.Header})
+			w.Write(append(byts, '\n'))
+		}
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		select {
+		case <-baked:
+		case <-r.Context().Done():
+			return
+		case <-n.closed:
+			return
+		}
+	}
+}
+
+func (n *Node) chainIDHandler(w http.ResponseWriter, r *http.Request, match []string) {
+	writeJSON(w, n.chainID)
+}
+
+func (n *Node) blockHandler(w http.ResponseWriter, r *http.Request, match []string) {
+	if s, ok := n.resolve(w, r, match[2]); ok {
+		writeJSON(w, s.block)
+	}
+}
+
+func (n *Node) header(w http.ResponseWriter, r *http.Request, match []string) {
+	if s, ok := n.resolve(w, r, match[2]); ok {
+		header := s.block.Header
+		header.Protocol, header.ChainID, header.Hash = s.block.Protocol, s.block.ChainID, s.block.Hash
+		writeJSON(w, header)
+	}
+}
+
+func (n *Node) balance(w http.ResponseWriter, r *http.Request, match []string) {
+	if s, ok := n.resolve(w, r, match[2]); ok {
+		if account, ok := s.accounts[match[3]]; ok {
+			writeJSON(w, account.Balance.String())
+			return
+		}
+		http.NotFound(w, r)
+	}
+}
+
+func (n *Node) counter(w http.ResponseWriter, r *http.Request, match []string) {
+	if s, ok := n.resolve(w, r, match[2]); ok {
+		if account, ok := s.accounts[match[3]]; ok {
+			writeJSON(w, strconv.Itoa(account.Counter))
+			return
+		}
+		http.NotFound(w, r)
+	}
+}
+
+func (n *Node) constantsHandler(w http.ResponseWriter, r *http.Request, match []string) {
+	if _, ok := n.resolve(w, r, match[2]); ok {
+		writeJSON(w, n.constants)
+	}
+}
+
+type right struct {
+	Level         int       `json:"level"`
+	Delegate      string    `json:"delegate"`
+	Priority      *int      `json:"priority,omitempty"`
+	Slots         []int     `json:"slots,omitempty"`
+	EstimatedTime time.Time `json:"estimated_time"`
+}
+
+func (n *Node) bakingRights(w http.ResponseWriter, r *http.Request, match []string) {
+	s, ok := n.resolve(w, r, match[2])
+	if !ok {
+		return
+	}
+
+	level := s.block.Header.Level + 1
+	if l, err := strconv.Atoi(r.URL.Query().Get("level")); err == nil {
+		level = l
+	}
+
+	maxPriority := len(n.delegates) - 1
+	if p, err := strconv.Atoi(r.URL.Query().Get("max_priority")); err == nil && p < maxPriority {
+		maxPriority = p
+	}
+
+	rights := []right{}
+	for priority := 0; priority <= maxPriority; priority++ {
+		delegate := n.delegates[(level+priority)%len(n.delegates)]
+		if d := r.URL.Query().Get("delegate"); d != "" && d != delegate {
+			continue
+		}
+
+		p := priority
+		rights = append(rights, right{
+			Level:         level,
+			Delegate:      delegate,
+			Priority:      &p,
+			EstimatedTime: n.estimatedTime(s.block, level),
+		})
+	}
+
+	writeJSON(w, rights)
+}
+
+func (n *Node) endorsingRights(w http.ResponseWriter, r *http.Request, match []string) {
+	s, ok := n.resolve(w, r, match[2])
+	if !ok {
+		return
+	}
+
+	level := s.block.Header.Level
+	if l, err := strconv.Atoi(r.URL.Query().Get("level")); err == nil {
+		level = l
+	}
+
+	rights := []right{}
+	if len(n.delegates) > 0 {
+		slots := map[string][]int{}
+		for slot := 0; slot < n.constants.EndorsersPerBlock; slot++ {
+			delegate := n.delegates[(level+slot)%len(n.delegates)]
+			slots[delegate] = append(slots[delegate], slot)
+		}
+
+		for _, delegate := range n.delegates {
+			if d := r.URL.Query().Get("delegate"); (d != "" && d != delegate) || len(slots[delegate]) == 0 {
+				continue
+			}
+
+			rights = append(rights, right{
+				Level:         level,
+				Delegate:      delegate,
+				Slots:         slots[delegate],
+				EstimatedTime: n.estimatedTime(s.block, level),
+			})
+			delete(slots, delegate)
+		}
+	}
+
+	writeJSON(w, rights)
+}
+
+func (n *Node) forge(w http.ResponseWriter, r *http.Request, match []string) {
+	var op goMXP.Operations
+	if !readJSON(w, r, &op) {
+		return
+	}
+
+	forged, err := goMXP.ForgeOperation(op.Branch, op.Contents...)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, goMXP.RPCError{Kind: "permanent", ID: "proto.alpha.operation.invalid", Msg: err.Error()})
+		return
+	}
+	n.forged[forged[64:]] = op
+
+	writeJSON(w, forged)
+}
+
+func (n *Node) parse(w http.ResponseWriter, r *http.Request, match []string) {
+	var input goMXP.UnforgeOperationWithRPCInput
+	if !readJSON(w, r, &input) {
+		return
+	}
+
+	var ops []goMXP.Operations
+	for _, data := range input.Operations {
+		op, ok := n.lookup(data.Data)
+		if !ok {
+			writeErrors(w, http.StatusBadRequest, goMXP.RPCError{Kind: "permanent", ID: "proto.alpha.operation.invalid", Msg: "operation was not forged by this node"})
+			return
+		}
+		op.Branch = data.Branch
+		ops = append(ops, op)
+	}
+
+	writeJSON(w, ops)
+}
+
+func (n *Node) preapply(w http.ResponseWriter, r *http.Request, match []string) {
+	var ops []goMXP.Operations
+	if !readJSON(w, r, &ops) {
+		return
+	}
+
+	pending := copyCounters(n.pending)
+	var applied []goMXP.Operations
+	for _, op := range ops {
+		if errs := n.validate(op, pending); len(errs) > 0 {
+			writeErrors(w, http.StatusInternalServerError, errs...)
+			return
+		}
+		applied = append(applied, withStatus(op, "applied"))
+	}
+
+	writeJSON(w, applied)
+}
+
+func (n *Node) inject(w http.ResponseWriter, r *http.Request, match []string) {
+	var signed string
+	if !readJSON(w, r, &signed) {
+		return
+	}
+
+	hash, err := goMXP.OperationHash(signed)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, goMXP.RPCError{Kind: "permanent", ID: "proto.alpha.operation.invalid", Msg: "operation is not a signed hex operation"})
+		return
+	}
+
+	op, ok := n.lookup(signed[64:])
+	if !ok {
+		writeErrors(w, http.StatusBadRequest, goMXP.RPCError{Kind: "permanent", ID: "proto.alpha.operation.invalid", Msg: "operation was not forged by this node"})
+		return
+	}
+
+	if errs := n.validate(op, n.pending); len(errs) > 0 {
+		writeErrors(w, http.StatusInternalServerError, errs...)
+		return
+	}
+
+	op.Protocol = Protocol
+	op.ChainID = n.chainID
+	op.Hash = hash
+	n.mempool = append(n.mempool, op)
+
+	writeJSON(w, op.Hash)
+}
+
+// resolve returns the snapshot of the block id (head, head~N, a level or a hash), or writes a 404.
+func (n *Node) resolve(w http.ResponseWriter, r *http.Request, id string) (snapshot, bool) {
+	level := -1
+	switch {
+	case id == "head":
+		level = len(n.blocks) - 1
+	case strings.HasPrefix(id, "head~"):
+		if back, err := strconv.Atoi(strings.TrimPrefix(id, "head~")); err == nil {
+			level = len(n.blocks) - 1 - back
+		}
+	default:
+		if l, err := strconv.Atoi(id); err == nil {
+			level = l
+			break
+		}
+		for i, s := range n.blocks {
+			if s.block.Hash == id {
+				level = i
+			}
+		}
+	}
+
+	if level < 0 || level >= len(n.blocks) {
+		http.NotFound(w, r)
+		return snapshot{}, false
+	}
+
+	return n.blocks[level], true
+}
+
+// lookup returns the operation forged by the node whose forged bytes, without branch, are data with or without a signature.
+func (n *Node) lookup(data string) (goMXP.Operations, bool) {
+	if op, ok := n.forged[data]; ok {
+		return op, true
+	}
+
+	if len(data) > 128 {
+		op, ok := n.forged[data[:len(data)-128]]
+		return op, ok
+	}
+
+	return goMXP.Operations{}, false
+}
+
+// validate checks the counters and balances of the contents of op against the state of the head
+// and the counters in pending, which it updates.
+func (n *Node) validate(op goMXP.Operations, pending map[string]int) []goMXP.RPCError {
+	spent := map[string]*big.Int{}
+	counters := copyCounters(pending)
+
+	for _, c := range op.Contents {
+		if c.Source == "" {
+			continue
+		}
+
+		account := n.account(c.Source)
+		expected := account.Counter + 1
+		if counters[c.Source] >= expected {
+			expected = counters[c.Source] + 1
+		}
+
+		found := int64(-1)
+		if c.Counter != nil && c.Counter.Big != nil {
+			found = c.Counter.Big.Int64()
+		}
+		switch {
+		case found < int64(expected):
+			return []goMXP.RPCError{{Kind: "temporary", ID: "proto.alpha.contract.counter_in_the_past", Contract: c.Source, Expected: goMXP.NewInt(expected), Found: c.Counter}}
+		case found > int64(expected):
+			return []goMXP.RPCError{{Kind: "temporary", ID: "proto.alpha.contract.counter_in_the_future", Contract: c.Source, Expected: goMXP.NewInt(expected), Found: c.Counter}}
+		}
+		counters[c.Source] = expected
+
+		cost := new(big.Int).Add(bigOf(c.Fee), bigOf(c.Amount))
+		cost.Add(cost, bigOf(c.Balance))
+		if spent[c.Source] == nil {
+			spent[c.Source] = new(big.Int)
+		}
+		spent[c.Source].Add(spent[c.Source], cost)
+
+		if spent[c.Source].Cmp(account.Balance) > 0 {
+			return []goMXP.RPCError{{Kind: "temporary", ID: "proto.alpha.contract.balance_too_low", Contract: c.Source, Balance: &goMXP.Int{Big: account.Balance}, Amount: &goMXP.Int{Big: cost}}}
+		}
+	}
+
+	for source, counter := range counters {
+		pending[source] = counter
+	}
+
+	return nil
+}
+
+// apply moves the balances and counters of op in the state of the head.
+func (n *Node) apply(op goMXP.Operations) {
+	for _, c := range op.Contents {
+		if c.Source == "" {
+			continue
+		}
+
+		source := n.account(c.Source)
+		if c.Counter != nil && c.Counter.Big != nil {
+			source.Counter = int(c.Counter.Big.Int64())
+		}
+		source.Balance.Sub(source.Balance, bigOf(c.Fee))
+		source.Balance.Sub(source.Balance, bigOf(c.Balance))
+
+		if c.Kind == goMXP.TRANSACTIONOP {
+			source.Balance.Sub(source.Balance, bigOf(c.Amount))
+			n.accounts[c.Source] = source
+
+			destination := n.account(c.Destination)
+			destination.Balance.Add(destination.Balance, bigOf(c.Amount))
+			n.accounts[c.Destination] = destination
+			continue
+		}
+
+		n.accounts[c.Source] = source
+	}
+}
+
+// account returns a copy of the current state of address.
+func (n *Node) account(address string) Account {
+	account, ok := n.accounts[address]
+	if !ok {
+		return Account{Balance: new(big.Int)}
+	}
+
+	return Account{Balance: new(big.Int).Set(account.Balance), Counter: account.Counter}
+}
+
+func (n *Node) estimatedTime(head *goMXP.Block, level int) time.Time {
+	return head.Header.Timestamp.Add(time.Duration(level-head.Header.Level) * time.Minute)
+}
+
+func withStatus(op goMXP.Operations, status string) goMXP.Operations {
+	contents := make([]goMXP.Contents, len(op.Contents))
+	for i, c := range op.Contents {
+		c.Metadata = &goMXP.ContentsMetadata{
+			BalanceUpdates:  []goMXP.BalanceUpdates{},
+			OperationResult: &goMXP.OperationResult{Status: status, ConsumedGas: c.GasLimit},
+		}
+		contents[i] = c
+	}
+	op.Contents = contents
+
+	return op
+}
+
+func blockHash(block *goMXP.Block) string {
+	byts, _ := json.Marshal(block.Header)
+	hash := blake2b.Sum256(byts)
+	return b58cencode(hash[:], blockPrefix)
+}
+
+func b58cencode(payload []byte, prefix []byte) string {
+	byts := append(append([]byte{}, prefix...), payload...)
+	first := sha256.Sum256(byts)
+	second := sha256.Sum256(first[:])
+	return base58.Encode(append(byts, second[:4]...))
+}
+
+func bigOf(i *goMXP.Int) *big.Int {
+	if i == nil || i.Big == nil {
+		return new(big.Int)
+	}
+
+	return i.Big
+}
+
+func copyAccounts(accounts map[string]Account) map[string]Account {
+	c := make(map[string]Account, len(accounts))
+	for address, account := range accounts {
+		c[address] = Account{Balance: new(big.Int).Set(account.Balance), Counter: account.Counter}
+	}
+
+	return c
+}
+
+func copyCounters(counters map[string]int) map[string]int {
+	c := make(map[string]int, len(counters))
+	for source, counter := range counters {
+		c[source] = counter
+	}
+
+	return c
+}
+
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	err := json.NewDecoder(r.Body).Decode(v)
+	if err != nil {
+		writeErrors(w, http.StatusBadRequest, goMXP.RPCError{Kind: "permanent", Err: "invalid_json", Msg: err.Error()})
+		return false
+	}
+
+	return true
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeErrors(w http.ResponseWriter, status int, errs ...goMXP.RPCError) {
+	if errs == nil {
+		errs = []goMXP.RPCError{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(errs)
+}
+
+func defaultConstants() goMXP.Constants {
+	return goMXP.Constants{
+		ProofOfWorkNonceSize:         8,
+		NonceLength:                  32,
+		MaxRevelationsPerBlock:       32,
+		MaxOperationDataLength:       16384,
+		MaxProposalsPerDelegate:      20,
+		PreservedCycles:              5,
+		BlocksPerCycle:               4096,
+		BlocksPerCommitment:          32,
+		BlocksPerRollSnapshot:        256,
+		BlocksPerVotingPeriod:        32768,
+		TimeBetweenBlocks:            []string{"60", "40"},
+		EndorsersPerBlock:            32,
+		HardGasLimitPerOperation:     goMXP.NewInt(1040000),
+		HardGasLimitPerBlock:         goMXP.NewInt(10400000),
+		ProofOfWorkThreshold:         "70368744177663",
+		TokensPerRoll:                "8000000000",
+		MichelsonMaximumTypeSize:     1000,
+		SeedNonceRevelationTip:       "125000",
+		OriginationSize:              257,
+		BlockSecurityDeposit:         goMXP.NewInt(512000000),
+		EndorsementSecurityDeposit:   goMXP.NewInt(64000000),
+		BlockReward:                  []*goMXP.Int{goMXP.NewInt(1250000), goMXP.NewInt(187500)},
+		EndorsementReward:            []*goMXP.Int{goMXP.NewInt(1250000), goMXP.NewInt(833333)},
+		CostPerByte:                  goMXP.NewInt(1000),
+		HardStorageLimitPerOperation: goMXP.NewInt(60000),
+	}
+}
@@ -0,0 +1,191 @@
+package goMXPtest
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	goMXP "github.com/goat-systems/go-MXP/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	alice = "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"
+	bob   = "tz1W3HW533csCBLor4NPtU79R2TT2sbKfJDH"
+)
+
+func transfer(counter, amount int) goMXP.Contents {
+	return goMXP.Contents{
+		Kind:         goMXP.TRANSACTIONOP,
+		Source:       alice,
+		Fee:          goMXP.NewInt(1000),
+		Counter:      goMXP.NewInt(counter),
+		GasLimit:     goMXP.NewInt(10200),
+		StorageLimit: goMXP.NewInt(0),
+		Amount:       goMXP.NewInt(amount),
+		Destination:  bob,
+	}
+}
+
+func Test_Node(t *testing.T) {
+	node := NewNode(WithAccount(alice, 1000000, 5), WithDelegates(alice, bob))
+	defer node.Close()
+
+	gt, err := goMXP.New(node.URL)
+	assert.Nil(t, err)
+
+	t.Run("serves blocks and accounts", func(t *testing.T) {
+		head, err := gt.Head()
+		assert.Nil(t, err)
+		assert.Equal(t, node.Head().Hash, head.Hash)
+		assert.Equal(t, 0, head.Header.Level)
+
+		balance, err := gt.Balance("head", alice)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(1000000), balance)
+
+		counter, err := gt.Counter(head.Hash, alice)
+		assert.Nil(t, err)
+		assert.Equal(t, 5, counter)
+
+		id, err := gt.ChainID()
+		assert.Nil(t, err)
+		assert.Equal(t, ChainID, id)
+
+		hash := head.Hash
+		rights, err := gt.BakingRights(goMXP.BakingRightsInput{BlockHash: &hash})
+		assert.Nil(t, err)
+		assert.Len(t, *rights, 2)
+		assert.Equal(t, bob, (*rights)[0].Delegate)
+
+		endorsing, err := gt.EndorsingRights(goMXP.EndorsingRightsInput{BlockHash: &hash})
+		assert.Nil(t, err)
+		assert.Len(t, *endorsing, 2)
+		assert.Len(t, (*endorsing)[0].Slots, 16)
+	})
+
+	t.Run("forges, preapplies, injects and bakes operations", func(t *testing.T) {
+		head := node.Head()
+		forged, err := gt.ForgeOperationWithRPC(goMXP.ForgeOperationWithRPCInput{
+			Blockhash: "head",
+			Branch:    head.Hash,
+			Contents:  []goMXP.Contents{transfer(6, 5000)},
+		})
+		assert.Nil(t, err)
+
+		ops, err := gt.PreapplyOperations(goMXP.PreapplyOperationsInput{
+			Blockhash: head.Hash,
+			Protocol:  Protocol,
+			Signature: "edsigtXomBKi5CTRf5cjATJWSyaRvhfYNHqSUGrn4SdbYRcGwQrUGjzEfQDTuqHhuA8b2d8NarZjz8TRf65WkpQmo423BtomS8Q",
+			Contents:  []goMXP.Contents{transfer(6, 5000)},
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "applied", ops[0].Contents[0].Metadata.OperationResult.Status)
+
+		signed := forged + strings.Repeat("00", 64)
+		hash, err := gt.InjectionOperation(goMXP.InjectionOperationInput{Operation: &signed})
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(hash, "o"))
+		assert.Len(t, node.Pending(), 1)
+
+		_, err = gt.InjectionOperation(goMXP.InjectionOperationInput{Operation: &signed})
+		var reqErr *goMXP.RequestError
+		assert.True(t, errors.As(err, &reqErr))
+		assert.True(t, reqErr.HasID("contract.counter_in_the_past"))
+
+		block := node.Bake()
+		assert.Equal(t, 1, block.Header.Level)
+		assert.Equal(t, head.Hash, block.Header.Predecessor)
+		assert.Equal(t, hash, block.Operations[3][0].Hash)
+		assert.Len(t, node.Pending(), 0)
+
+		balance, err := gt.Balance("head", alice)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(1000000-5000-1000), balance)
+
+		balance, err = gt.Balance(head.Hash, alice)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(1000000), balance)
+
+		balance, err = gt.Balance("head", bob)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(5000), balance)
+
+		counter, err := gt.Counter("head", alice)
+		assert.Nil(t, err)
+		assert.Equal(t, 6, counter)
+	})
+
+	t.Run("rejects operations above balance", func(t *testing.T) {
+		_, err := gt.PreapplyOperations(goMXP.PreapplyOperationsInput{
+			Blockhash: "head",
+			Protocol:  Protocol,
+			Signature: "edsigtXomBKi5CTRf5cjATJWSyaRvhfYNHqSUGrn4SdbYRcGwQrUGjzEfQDTuqHhuA8b2d8NarZjz8TRf65WkpQmo423BtomS8Q",
+			Contents:  []goMXP.Contents{transfer(7, 2000000)},
+		})
+		var reqErr *goMXP.RequestError
+		assert.True(t, errors.As(err, &reqErr))
+		assert.True(t, reqErr.HasID("contract.balance_too_low"))
+	})
+
+	t.Run("streams heads", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		heads, _ := gt.MonitorHeads(ctx, goMXP.MonitorHeadsInput{})
+		assert.Equal(t, node.Head().Hash, receive(t, heads).Hash)
+
+		block := node.Bake()
+		assert.Equal(t, block.Hash, receive(t, heads).Hash)
+	})
+
+	t.Run("injects failures", func(t *testing.T) {
+		node.FailRequests(`/context/contracts/[^/]+/balance$`, 1, http.StatusServiceUnavailable)
+
+		_, err := gt.Balance("head", alice)
+		var reqErr *goMXP.RequestError
+		assert.True(t, errors.As(err, &reqErr))
+		assert.Equal(t, http.StatusServiceUnavailable, reqErr.StatusCode)
+
+		_, err = gt.Balance("head", alice)
+		assert.Nil(t, err)
+
+		node.FailRequests(`^/injection/operation$`, 0, http.StatusInternalServerError, goMXP.RPCError{Kind: "temporary", ID: "failure"})
+		op := "00"
+		for i := 0; i < 2; i++ {
+			_, err = gt.InjectionOperation(goMXP.InjectionOperationInput{Operation: &op})
+			checkErr(t, err, "rpc error (temporary): failure")
+		}
+
+		node.ClearFailures()
+		_, err = gt.InjectionOperation(goMXP.InjectionOperationInput{Operation: &op})
+		checkErr(t, err, "rpc error (permanent): proto.alpha.operation.invalid")
+	})
+
+	t.Run("handles unknown blocks", func(t *testing.T) {
+		_, err := gt.Block(100)
+		var reqErr *goMXP.RequestError
+		assert.True(t, errors.As(err, &reqErr))
+		assert.Equal(t, http.StatusNotFound, reqErr.StatusCode)
+	})
+}
+
+func receive(t *testing.T, heads <-chan *goMXP.Block) *goMXP.Block {
+	select {
+	case head := <-heads:
+		return head
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for head")
+		return nil
+	}
+}
+
+func checkErr(t *testing.T, err error, contains string) {
+	if assert.NotNil(t, err) {
+		assert.Contains(t, err.Error(), contains)
+	}
+}
@@ -2,13 +2,14 @@ package goMXP
 
 import (
 	"bytes"
+	"context"
+	"crypto/tls"
 	"encoding/json"
 	"fmt"
-	"io/ioutil"
-	"net"
 	"net/http"
-	"regexp"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/pkg/errors"
@@ -17,37 +18,209 @@ import (
 // MUTEZ is mutez on the MXP network
 const MUTEZ = 1000000
 
-var (
-	regRPCError = regexp.MustCompile(`\s?\{\s?\"(kind|error)\"\s?:\s?\"[^,]+\"\s?,\s?\"(kind|error)"\s?:\s?\"[^}]+\s?\}\s?`)
-)
-
 /*
 GoMXP contains a client (http.Client), network contents, and the host of the node. Gives access to
 RPC related functions.
 */
 type GoMXP struct {
-	client           client
+	cacheHits        uint64 // first for 64-bit alignment of atomic operations
+	cacheMisses      uint64
+	cfg              atomic.Value // *config
+	cfgMu            sync.Mutex
+	constantsMu      sync.Mutex
 	networkConstants *Constants
 	host             string
+	limiter          limiter
 }
 
 /*
-RPCError represents and RPC error
+RPCError represents a single error returned by the MXP RPC. Protocol errors are identified by ID
+(e.g. proto.006-PsCARTHA.contract.balance_too_low), shell errors by Err. Fields holds every field
+of the error as returned by the node, including the ones without a dedicated field.
 */
 type RPCError struct {
-	Kind string `json:"kind"`
-	Err  string `json:"error"`
+	Kind     string                     `json:"kind"`
+	ID       string                     `json:"id,omitempty"`
+	Err      string                     `json:"error,omitempty"`
+	Msg      string                     `json:"msg,omitempty"`
+	Contract string                     `json:"contract,omitempty"`
+	Amount   *Int                       `json:"amount,omitempty"`
+	Balance  *Int                       `json:"balance,omitempty"`
+	Expected *Int                       `json:"expected,omitempty"`
+	Found    *Int                       `json:"found,omitempty"`
+	Location *int                       `json:"location,omitempty"`
+	Fields   map[string]json.RawMessage `json:"-"`
 }
 
 func (r *RPCError) Error() string {
+	if r.Err == "" {
+		return fmt.Sprintf("rpc error (%s): %s", r.Kind, r.ID)
+	}
+
 	return fmt.Sprintf("rpc error (%s): %s", r.Kind, r.Err)
 }
 
+/*
+UnmarshalJSON implements the json.Unmarshaler interface for RPCError. Fields that do not
+decode into their dedicated type are still available in Fields.
+
+Parameters:
+
+	b:
+		The byte representation of an RPCError.
+*/
+func (r *RPCError) UnmarshalJSON(b []byte) error {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(b, &fields)
+	if err != nil {
+		return err
+	}
+
+	*r = RPCError{Fields: fields}
+	for key, dst := range map[string]*string{
+		"kind":     &r.Kind,
+		"id":       &r.ID,
+		"error":    &r.Err,
+		"msg":      &r.Msg,
+		"contract": &r.Contract,
+	} {
+		if v, ok := fields[key]; ok {
+			json.Unmarshal(v, dst)
+		}
+	}
+
+	for key, dst := range map[string]**Int{
+		"amount":   &r.Amount,
+		"balance":  &r.Balance,
+		"expected": &r.Expected,
+		"found":    &r.Found,
+	} {
+		if v, ok := fields[key]; ok {
+			if i, err := newInt(v); err == nil {
+				*dst = i
+			}
+		}
+	}
+
+	if v, ok := fields["location"]; ok {
+		var location int
+		if err := json.Unmarshal(v, &location); err == nil {
+			r.Location = &location
+		}
+	}
+
+	return nil
+}
+
+/*
+HasID reports whether the error matches id, which is either the full error ID or its last dot separated
+segments (e.g. counter_in_the_past, contract.balance_too_low or gas_exhausted.operation). Segments must
+match whole and at the end of the ID, so proto or contract alone match nothing.
+
+Parameters:
+
+	id:
+		The error ID to match.
+*/
+func (r *RPCError) HasID(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	return r.Err == id || r.ID == id || strings.HasSuffix(r.ID, "."+id)
+}
+
 /*
 RPCErrors represents multiple RPCError(s).s
 */
 type RPCErrors []RPCError
 
+/*
+RequestError is returned by every RPC function when a request fails. It carries the request,
+the HTTP status and body of the response, and every error the node returned. Transport failures,
+where no response was received, have a StatusCode of 0 and the cause in Err.
+*/
+type RequestError struct {
+	Method     string
+	Path       string
+	StatusCode int
+	Body       []byte
+	Errors     RPCErrors
+	Err        error
+}
+
+func (r *RequestError) Error() string {
+	if r.Err != nil {
+		return r.Err.Error()
+	}
+
+	if len(r.Errors) > 0 {
+		var errs []string
+		for i := range r.Errors {
+			errs = append(errs, r.Errors[i].Error())
+		}
+		return strings.Join(errs, ", ")
+	}
+
+	return fmt.Sprintf("response returned code %d with body %s", r.StatusCode, string(r.Body))
+}
+
+// Unwrap returns the transport error, or the first error returned by the node.
+func (r *RequestError) Unwrap() error {
+	if r.Err != nil {
+		return r.Err
+	}
+
+	if len(r.Errors) > 0 {
+		return &r.Errors[0]
+	}
+
+	return nil
+}
+
+// Transport reports whether the request failed before a response was received.
+func (r *RequestError) Transport() bool {
+	return r.StatusCode == 0
+}
+
+/*
+HasID reports whether any error returned by the node matches id. See RPCError.HasID.
+
+Parameters:
+
+	id:
+		The error ID to match.
+*/
+func (r *RequestError) HasID(id string) bool {
+	for i := range r.Errors {
+		if r.Errors[i].HasID(id) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+IsRPCError reports whether err is, or wraps, a RequestError with an error matching id.
+
+Parameters:
+
+	err:
+		An error returned by GoMXP.
+
+	id:
+		The error ID to match (e.g. counter_in_the_past, balance_too_low, gas_exhausted).
+*/
+func IsRPCError(err error, id string) bool {
+	var reqErr *RequestError
+	if errors.As(err, &reqErr) {
+		return reqErr.HasID(id)
+	}
+
+	return false
+}
+
 type rpcOptions struct {
 	Key   string
 	Value string
@@ -59,42 +232,177 @@ type client interface {
 }
 
 /*
-New returns a pointer to a GoMXP and initializes the library with the host's MXP netowrk constants.
+Option configures a GoMXP created with New.
+*/
+type Option func(*options)
+
+type options struct {
+	timeout    time.Duration
+	tlsConfig  *tls.Config
+	client     client
+	headers    http.Header
+	constants  *Constants
+	lazy       bool
+	middleware []Middleware
+	rateLimit  *RateLimit
+	cache      Cache
+	retry      *RetryPolicy
+}
+
+/*
+WithTimeout sets the timeout of every request, including the time to read the response. Defaults to 10s.
+It is ignored if WithHTTPClient is used.
+
+Parameters:
+
+	timeout:
+		The request timeout.
+*/
+func WithTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.timeout = timeout
+	}
+}
+
+/*
+WithTLSConfig sets the TLS configuration used to reach the node. It is ignored if WithHTTPClient is used.
+
+Parameters:
+
+	config:
+		The TLS configuration.
+*/
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = config
+	}
+}
+
+/*
+WithHTTPClient sets the http.Client used to reach the node.
+
+Parameters:
+
+	client:
+		A pointer to an http.Client.
+*/
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
 
+/*
+WithHeader adds a header to every request.
+
+Parameters:
+
+	key:
+		The header name.
+
+	value:
+		The header value.
+*/
+func WithHeader(key, value string) Option {
+	return func(o *options) {
+		o.headers.Add(key, value)
+	}
+}
+
+/*
+WithUserAgent sets the User-Agent header of every request.
+
+Parameters:
+
+	userAgent:
+		The user agent.
+*/
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.headers.Set("User-Agent", userAgent)
+	}
+}
+
+/*
+WithConstants preloads the network constants, so New does not fetch them from the node.
+
+Parameters:
+
+	constants:
+		MXP Network Constants.
+*/
+func WithConstants(constants Constants) Option {
+	return func(o *options) {
+		o.constants = &constants
+	}
+}
+
+/*
+WithLazyConstants defers fetching the network constants until a function needs them, so New does
+not reach the node.
+*/
+func WithLazyConstants() Option {
+	return func(o *options) {
+		o.lazy = true
+	}
+}
+
+/*
+New returns a pointer to a GoMXP and initializes the library with the host's MXP netowrk constants.
+Unless WithConstants or WithLazyConstants is used, New fetches the constants from the node.
 
 Parameters:
 
 	host:
 		A MXP node.
+
+	opts:
+		Options that configure the GoMXP (e.g. WithTimeout, WithLazyConstants).
 */
-func New(host string) (*GoMXP, error) {
-	gt := &GoMXP{
-		client: &http.Client{
-			Timeout: time.Second * 10,
-			Transport: &http.Transport{
-				Dial: (&net.Dialer{
-					Timeout: 10 * time.Second,
-				}).Dial,
-				TLSHandshakeTimeout: 10 * time.Second,
-			},
-		},
-		host: cleanseHost(host),
-	}
-
-	block, err := gt.Head()
-	if err != nil {
-		return gt, errors.Wrap(err, "could not initialize library with network constants")
+func New(host string, opts ...Option) (*GoMXP, error) {
+	gt := &GoMXP{host: cleanseHost(host)}
+	o := gt.configure(opts)
+
+	if o.constants != nil || o.lazy {
+		return gt, nil
 	}
 
-	constants, err := gt.Constants(block.Hash)
+	err := gt.initNetworkConstants(context.Background())
 	if err != nil {
-		return gt, errors.Wrap(err, "could not initialize library with network constants")
+		return gt, err
 	}
-	gt.networkConstants = &constants
 
 	return gt, nil
 }
 
+func (t *GoMXP) initNetworkConstants(ctx context.Context) error {
+	_, err := t.constants(ctx)
+	return err
+}
+
+// constants returns the network constants, fetching them from the node if they were not set yet.
+func (t *GoMXP) constants(ctx context.Context) (*Constants, error) {
+	t.constantsMu.Lock()
+	defer t.constantsMu.Unlock()
+
+	if t.networkConstants != nil {
+		return t.networkConstants, nil
+	}
+
+	block, err := t.HeadContext(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize library with network constants")
+	}
+
+	constants, err := t.ConstantsContext(ctx, block.Hash)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not initialize library with network constants")
+	}
+	t.networkConstants = &constants
+
+	return t.networkConstants, nil
+}
+
 /*
 SetClient overrides GoMXP's client. *http.Client satisfies the client interface.
 
@@ -104,7 +412,9 @@ Parameters:
 		A pointer to an http.Client.
 */
 func (t *GoMXP) SetClient(client *http.Client) {
-	t.client = client
+	t.update(func(c *config) {
+		c.client = client
+	})
 }
 
 /*
@@ -116,11 +426,63 @@ Parameters:
 		MXP Network Constants.
 */
 func (t *GoMXP) SetConstants(constants Constants) {
+	t.constantsMu.Lock()
+	defer t.constantsMu.Unlock()
 	t.networkConstants = &constants
 }
 
-func (t *GoMXP) post(path string, body []byte, opts ...rpcOptions) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s%s", t.host, path), bytes.NewBuffer(body))
+/*
+SetChain sets the chain GoMXP's RPC functions query. Defaults to main. It can be overridden
+for a single call with WithChain.
+
+Parameters:
+
+	chain:
+		main, test, or a chain ID (e.g. NetXdQprcVkpaWU).
+*/
+func (t *GoMXP) SetChain(chain string) {
+	t.update(func(c *config) {
+		c.chain = chain
+	})
+}
+
+type chainKey struct{}
+
+/*
+WithChain returns a copy of ctx that makes the Context variants of GoMXP's RPC functions query
+chain instead of the chain set with SetChain.
+
+Parameters:
+
+	ctx:
+		The parent context.
+
+	chain:
+		main, test, or a chain ID (e.g. NetXdQprcVkpaWU).
+*/
+func WithChain(ctx context.Context, chain string) context.Context {
+	return context.WithValue(ctx, chainKey{}, chain)
+}
+
+// chainOf returns the chain a call bound to ctx queries.
+func (t *GoMXP) chainOf(ctx context.Context) string {
+	if chain, ok := ctx.Value(chainKey{}).(string); ok && chain != "" {
+		return chain
+	}
+
+	if chain := t.config().chain; chain != "" {
+		return chain
+	}
+
+	return "main"
+}
+
+func (t *GoMXP) chainPath(ctx context.Context) string {
+	return fmt.Sprintf("/chains/%s", t.chainOf(ctx))
+}
+
+func (t *GoMXP) post(ctx context.Context, path string, body []byte, opts ...rpcOptions) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s%s", t.host, path), bytes.NewBuffer(body))
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to construct request")
 	}
@@ -130,8 +492,8 @@ func (t *GoMXP) post(path string, body []byte, opts ...rpcOptions) ([]byte, erro
 	return t.do(req)
 }
 
-func (t *GoMXP) get(path string, opts ...rpcOptions) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", t.host, path), nil)
+func (t *GoMXP) get(ctx context.Context, path string, opts ...rpcOptions) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", t.host, path), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to construct request")
 	}
@@ -141,8 +503,8 @@ func (t *GoMXP) get(path string, opts ...rpcOptions) ([]byte, error) {
 	return t.do(req)
 }
 
-func (t *GoMXP) delete(path string, opts ...rpcOptions) ([]byte, error) {
-	req, err := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s%s", t.host, path), nil)
+func (t *GoMXP) delete(ctx context.Context, path string, opts ...rpcOptions) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, fmt.Sprintf("%s%s", t.host, path), nil)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to construct request")
 	}
@@ -153,29 +515,51 @@ func (t *GoMXP) delete(path string, opts ...rpcOptions) ([]byte, error) {
 }
 
 func (t *GoMXP) do(req *http.Request) ([]byte, error) {
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := t.client.Do(req)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to complete request")
+	cfg := t.config()
+	cfg.setHeaders(req)
+	if byts, ok := t.cached(cfg, req); ok {
+		return byts, nil
 	}
 
-	byts, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return byts, errors.Wrap(err, "could not read response body")
+	var byts []byte
+	var err error
+	if cfg.retryPolicy.enabled(req) {
+		byts, err = t.doWithRetry(cfg, req)
+	} else {
+		byts, _, err = t.attempt(cfg, req, 1)
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return byts, fmt.Errorf("response returned code %d with body %s", resp.StatusCode, string(byts))
+	if err == nil {
+		t.store(cfg, req, byts)
 	}
 
-	err = handleRPCError(byts)
+	return byts, err
+}
+
+// attempt sends req once, as its n-th attempt, through the rate limiter and the middleware chain. The returned response has its body consumed and closed; it is nil on transport errors.
+func (t *GoMXP) attempt(cfg *config, req *http.Request, n int) ([]byte, *http.Response, error) {
+	release, err := t.limiter.wait(req.Context())
 	if err != nil {
-		return byts, err
+		return nil, nil, &RequestError{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Err:    errors.Wrap(err, "failed to wait for rate limit"),
+		}
+	}
+	defer release()
+
+	call := &RPCCall{
+		Request:  req,
+		Attempt:  n,
+		Template: pathTemplate(req.URL.Path),
 	}
+	cfg.handler()(call)
 
-	t.client.CloseIdleConnections()
+	if call.StatusCode == http.StatusTooManyRequests {
+		t.limiter.pause(call.Response)
+	}
 
-	return byts, nil
+	return call.Body, call.Response, call.Err
 }
 
 func constructQueryParams(req *http.Request, opts ...rpcOptions) {
@@ -187,23 +571,45 @@ func constructQueryParams(req *http.Request, opts ...rpcOptions) {
 	req.URL.RawQuery = q.Encode()
 }
 
-func handleRPCError(resp []byte) error {
-	if regRPCError.Match(resp) {
-		rpcErrors := RPCErrors{}
-		err := json.Unmarshal(resp, &rpcErrors)
-		if err != nil {
+func handleRPCError(req *http.Request, statusCode int, resp []byte) error {
+	rpcErrors := parseRPCErrors(resp)
+	if statusCode == http.StatusOK && len(rpcErrors) == 0 {
+		return nil
+	}
+
+	return &RequestError{
+		Method:     req.Method,
+		Path:       req.URL.Path,
+		StatusCode: statusCode,
+		Body:       resp,
+		Errors:     rpcErrors,
+	}
+}
+
+// parseRPCErrors returns the errors in resp if it is an RPC error list: a list of objects that each have a kind and an id or error.
+func parseRPCErrors(resp []byte) RPCErrors {
+	trimmed := bytes.TrimSpace(resp)
+	if len(trimmed) == 0 || trimmed[0] != '[' {
+		return nil
+	}
+
+	var rpcErrors RPCErrors
+	err := json.Unmarshal(trimmed, &rpcErrors)
+	if err != nil || len(rpcErrors) == 0 {
+		return nil
+	}
+
+	for _, rpcError := range rpcErrors {
+		if rpcError.Kind == "" || (rpcError.ID == "" && rpcError.Err == "") {
 			return nil
 		}
-		return &rpcErrors[0]
 	}
 
-	return nil
+	return rpcErrors
 }
 
 func cleanseHost(host string) string {
-	if host[len(host)-1] == '/' {
-		host = host[:len(host)-1]
-	}
+	host = strings.TrimSuffix(host, "/")
 	if !strings.HasPrefix(host, "http://") && !strings.HasPrefix(host, "https://") {
 		host = fmt.Sprintf("http://%s", host) //default to http
 	}
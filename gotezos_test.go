@@ -1,13 +1,18 @@
 package goMXP
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -70,13 +75,81 @@ func Test_New(t *testing.T) {
 	}
 }
 
+func Test_New_options(t *testing.T) {
+	var requests int32
+	var headers atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		headers.Store(r.Header.Clone())
+		gtGoldenHTTPMock(blankHandler).ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	t.Run("preloaded constants", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		gt, err := New(server.URL, WithConstants(*expectedConstants(t)))
+		assert.Nil(t, err)
+		assert.Equal(t, expectedConstants(t), gt.networkConstants)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("creates offline with lazy constants", func(t *testing.T) {
+		down := httptest.NewServer(blankHandler)
+		down.Close()
+
+		gt, err := New(down.URL, WithLazyConstants())
+		assert.Nil(t, err)
+		assert.Nil(t, gt.networkConstants)
+
+		_, err = gt.Cycle(10)
+		checkErr(t, true, "could not initialize library with network constants", err)
+	})
+
+	t.Run("fetches lazy constants on first use", func(t *testing.T) {
+		atomic.StoreInt32(&requests, 0)
+		gt, err := New(server.URL, WithLazyConstants())
+		assert.Nil(t, err)
+		assert.Equal(t, int32(0), atomic.LoadInt32(&requests))
+
+		constants, err := gt.constants(context.Background())
+		assert.Nil(t, err)
+		assert.Equal(t, expectedConstants(t), constants)
+		assert.Equal(t, int32(2), atomic.LoadInt32(&requests))
+	})
+
+	t.Run("sends headers", func(t *testing.T) {
+		_, err := New(server.URL, WithHeader("Authorization", "Bearer token"), WithUserAgent("payouts/1.0"))
+		assert.Nil(t, err)
+
+		h := headers.Load().(http.Header)
+		assert.Equal(t, "Bearer token", h.Get("Authorization"))
+		assert.Equal(t, "payouts/1.0", h.Get("User-Agent"))
+		assert.Equal(t, "application/json", h.Get("Content-Type"))
+	})
+
+	t.Run("configures client", func(t *testing.T) {
+		tlsConfig := &tls.Config{ServerName: "node"}
+		gt, err := New(server.URL, WithLazyConstants(), WithTimeout(time.Minute), WithTLSConfig(tlsConfig))
+		assert.Nil(t, err)
+
+		client := gt.config().client.(*http.Client)
+		assert.Equal(t, time.Minute, client.Timeout)
+		assert.Equal(t, tlsConfig, client.Transport.(*http.Transport).TLSClientConfig)
+
+		custom := &http.Client{}
+		gt, err = New(server.URL, WithLazyConstants(), WithHTTPClient(custom))
+		assert.Nil(t, err)
+		assert.Equal(t, custom, gt.config().client)
+	})
+}
+
 func Test_SetClient(t *testing.T) {
 	gt := GoMXP{}
 
 	client := &http.Client{}
 	gt.SetClient(client)
 
-	assert.Equal(t, client, gt.client)
+	assert.Equal(t, client, gt.config().client)
 }
 
 func Test_SetConstants(t *testing.T) {
@@ -88,6 +161,44 @@ func Test_SetConstants(t *testing.T) {
 	assert.Equal(t, constants, *gt.networkConstants)
 }
 
+func Test_SetChain(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.String())
+		switch {
+		case regInjectionOperation.MatchString(r.URL.String()):
+			w.Write([]byte(`"ooYSSxYcgreJQtrKAjBTTFkRsBrBh3cVJyBvGhcXVGd6sWujVWw"`))
+		default:
+			w.Write(readResponse(balance))
+		}
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	_, err = gt.Balance(mockBlockHash, mockAddressTz1)
+	assert.Nil(t, err)
+
+	gt.SetChain("test")
+	_, err = gt.Balance(mockBlockHash, mockAddressTz1)
+	assert.Nil(t, err)
+
+	_, err = gt.BalanceContext(WithChain(context.Background(), "NetXdQprcVkpaWU"), mockBlockHash, mockAddressTz1)
+	assert.Nil(t, err)
+
+	operation := "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add3"
+	_, err = gt.InjectionOperation(InjectionOperationInput{Operation: &operation})
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{
+		fmt.Sprintf("/chains/main/blocks/%s/context/contracts/%s/balance", mockBlockHash, mockAddressTz1),
+		fmt.Sprintf("/chains/test/blocks/%s/context/contracts/%s/balance", mockBlockHash, mockAddressTz1),
+		fmt.Sprintf("/chains/NetXdQprcVkpaWU/blocks/%s/context/contracts/%s/balance", mockBlockHash, mockAddressTz1),
+		"/injection/operation?chain_id=test",
+	}, requests)
+}
+
 func Test_post(t *testing.T) {
 	type input struct {
 		handler http.Handler
@@ -172,7 +283,7 @@ func Test_post(t *testing.T) {
 			gt, err := New(server.URL)
 			assert.Nil(t, err)
 
-			p, err := gt.post(tt.input.post, tt.input.body, tt.input.opts...)
+			p, err := gt.post(context.Background(), tt.input.post, tt.input.body, tt.input.opts...)
 			checkErr(t, tt.want.err, "", err)
 			assert.Equal(t, tt.want.resp, p)
 		})
@@ -256,13 +367,39 @@ func Test_get(t *testing.T) {
 			gt, err := New(server.URL)
 			assert.Nil(t, err)
 
-			p, err := gt.get(tt.input.get, tt.input.params...)
+			p, err := gt.get(context.Background(), tt.input.get, tt.input.params...)
 			checkErr(t, tt.want.err, "", err)
 			assert.Equal(t, tt.want.resp, p)
 		})
 	}
 }
 
+func Test_requestContext(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		_, err := gt.BlockContext(ctx, 50)
+		checkErr(t, true, "context deadline exceeded", err)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := gt.HeadContext(ctx)
+		checkErr(t, true, "context canceled", err)
+	})
+}
+
 func Test_do(t *testing.T) {
 	type input struct {
 		handler http.Handler
@@ -359,42 +496,84 @@ func Test_do(t *testing.T) {
 func Test_handleRPCError(t *testing.T) {
 	cases := []struct {
 		name        string
+		statusCode  int
 		resp        []byte
 		wantErr     bool
 		errContents string
 	}{
 		{
 			"found an rpc error",
+			http.StatusOK,
 			[]byte(`[{"kind":"some_kind","error":"some_error"}]`),
 			true,
 			"rpc error",
 		},
+		{
+			"found a protocol error",
+			http.StatusOK,
+			[]byte(`[{"kind":"temporary","id":"proto.006-PsCARTHA.contract.counter_in_the_past","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","expected":"10","found":"9"}]`),
+			true,
+			"rpc error (temporary): proto.006-PsCARTHA.contract.counter_in_the_past",
+		},
 		{
 			"failed to unmarshal rpc error",
+			http.StatusOK,
 			[]byte(`error`),
 			false,
 			"",
 		},
 		{
 			"did not find an rpc error",
+			http.StatusOK,
 			[]byte(`some other data`),
 			false,
 			"",
 		},
+		{
+			"does not mistake nested errors for an rpc error",
+			http.StatusOK,
+			readResponse(invalidblocks),
+			false,
+			"",
+		},
 		{
 			"handles empty",
+			http.StatusOK,
 			[]byte{},
 			false,
 			"",
 		},
+		{
+			"handles non 200 without rpc errors",
+			http.StatusNotFound,
+			[]byte(`not found`),
+			true,
+			"response returned code 404 with body not found",
+		},
+		{
+			"handles non 200 with rpc errors",
+			http.StatusInternalServerError,
+			[]byte(`[{"kind":"temporary","id":"proto.006-PsCARTHA.gas_exhausted.operation"}]`),
+			true,
+			"rpc error (temporary): proto.006-PsCARTHA.gas_exhausted.operation",
+		},
 	}
 
 	for _, tt := range cases {
 		t.Run(tt.name, func(t *testing.T) {
-			err := handleRPCError(tt.resp)
+			req, err := http.NewRequest(http.MethodGet, "http://localhost/some/endpoint", nil)
+			assert.Nil(t, err)
+
+			err = handleRPCError(req, tt.statusCode, tt.resp)
 			if tt.wantErr {
 				assert.NotNil(t, err)
 				assert.Contains(t, err.Error(), tt.errContents)
+
+				reqErr, ok := err.(*RequestError)
+				assert.True(t, ok)
+				assert.Equal(t, "/some/endpoint", reqErr.Path)
+				assert.Equal(t, tt.statusCode, reqErr.StatusCode)
+				assert.Equal(t, tt.resp, reqErr.Body)
 			} else {
 				assert.Nil(t, err)
 			}
@@ -402,6 +581,62 @@ func Test_handleRPCError(t *testing.T) {
 	}
 }
 
+func Test_RequestError(t *testing.T) {
+	server := httptest.NewServer(gtGoldenHTTPMock(balanceHandlerMock([]byte(`[{"kind":"temporary","id":"proto.006-PsCARTHA.contract.balance_too_low","contract":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc","balance":"100","amount":"200"},{"kind":"temporary","id":"proto.006-PsCARTHA.michelson_v1.script_rejected","location":42},{"kind":"temporary","id":"proto.006-PsCARTHA.gas_exhausted.operation"}]`), blankHandler)))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	_, err = gt.Balance(mockBlockHash, mockAddressTz1)
+	assert.NotNil(t, err)
+
+	var reqErr *RequestError
+	assert.True(t, errors.As(err, &reqErr))
+	assert.False(t, reqErr.Transport())
+	assert.Equal(t, http.MethodGet, reqErr.Method)
+	assert.Equal(t, "/chains/main/blocks/"+mockBlockHash+"/context/contracts/"+mockAddressTz1+"/balance", reqErr.Path)
+	assert.Len(t, reqErr.Errors, 3)
+	assert.Equal(t, "tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc", reqErr.Errors[0].Contract)
+	assert.Equal(t, NewInt(100).Big.String(), reqErr.Errors[0].Balance.Big.String())
+	assert.Equal(t, NewInt(200).Big.String(), reqErr.Errors[0].Amount.Big.String())
+	assert.Equal(t, 42, *reqErr.Errors[1].Location)
+	assert.Contains(t, reqErr.Errors[1].Fields, "location")
+
+	for _, id := range []string{
+		"balance_too_low",
+		"contract.balance_too_low",
+		"proto.006-PsCARTHA.contract.balance_too_low",
+		"script_rejected",
+		"gas_exhausted.operation",
+	} {
+		assert.True(t, IsRPCError(err, id), id)
+	}
+
+	for _, id := range []string{
+		"counter_in_the_past",
+		"proto",
+		"contract",
+		"006-PsCARTHA.contract",
+		"gas_exhausted",
+		"exhausted",
+		"exhausted.operation",
+		"too_low",
+		"",
+	} {
+		assert.False(t, IsRPCError(err, id), id)
+	}
+
+	var rpcErr *RPCError
+	assert.True(t, errors.As(err, &rpcErr))
+	assert.Equal(t, "proto.006-PsCARTHA.contract.balance_too_low", rpcErr.ID)
+
+	server.Close()
+	_, err = gt.Balance(mockBlockHash, mockAddressTz1)
+	assert.True(t, errors.As(err, &reqErr))
+	assert.True(t, reqErr.Transport())
+}
+
 func Test_constructQuery(t *testing.T) {
 	cases := []struct {
 		name string
@@ -1,39 +1,142 @@
 package goMXP
 
-import "math/big"
+import (
+	"context"
+	"math/big"
+	"net/http"
+	"net/url"
+	"time"
+)
 
-// IFace is an interface mocking a GoMXP object.
+// IFace is an interface mocking a GoMXP object. It covers every public method of GoMXP and is
+// composed of role interfaces, so a service can depend on the narrow slice it uses instead.
 type IFace interface {
-	ActiveChains() (ActiveChains, error)
-	BakingRights(input BakingRightsInput) (*BakingRights, error)
-	Balance(blockhash, address string) (*big.Int, error)
+	BlockReader
+	AccountReader
+	DelegateReader
+	OperationInjector
+	NetworkInspector
+
+	CacheStats() CacheStats
+	Configure(opts ...Option)
+	RPC(method, path string, query url.Values, body, out interface{}) error
+	RPCContext(ctx context.Context, method, path string, query url.Values, body, out interface{}) error
+	SetCache(cache Cache)
+	SetChain(chain string)
+	SetClient(client *http.Client)
+	SetConstants(constants Constants)
+	SetRateLimit(limit RateLimit)
+	SetRetryPolicy(policy RetryPolicy)
+	Use(middleware ...Middleware)
+}
+
+// BlockReader reads, streams and follows the blocks of the chain.
+type BlockReader interface {
 	Block(id interface{}) (*Block, error)
+	BlockAtTime(timestamp time.Time, match TimeMatch) (*Block, error)
+	BlockAtTimeContext(ctx context.Context, timestamp time.Time, match TimeMatch) (*Block, error)
+	BlockContext(ctx context.Context, id interface{}) (*Block, error)
+	BlockRange(ctx context.Context, input BlockRangeInput) (<-chan BlockRangeResult, error)
+	BlockRangeFunc(ctx context.Context, input BlockRangeInput, fn func(result BlockRangeResult) error) (int, error)
 	Blocks(input BlocksInput) ([][]string, error)
-	Bootstrap() (Bootstrap, error)
-	ChainID() (string, error)
-	Checkpoint() (Checkpoint, error)
-	Commit() (string, error)
-	Connections() (Connections, error)
-	Constants(blockhash string) (Constants, error)
+	BlocksContext(ctx context.Context, input BlocksInput) ([][]string, error)
+	DeleteInvalidBlock(blockHash string) error
+	DeleteInvalidBlockContext(ctx context.Context, blockHash string) error
+	Hash(id interface{}) (string, error)
+	HashContext(ctx context.Context, id interface{}) (string, error)
+	Head() (*Block, error)
+	HeadContext(ctx context.Context) (*Block, error)
+	Header(id interface{}) (*Header, error)
+	HeaderContext(ctx context.Context, id interface{}) (*Header, error)
+	HeaderShell(id interface{}) (*Header, error)
+	HeaderShellContext(ctx context.Context, id interface{}) (*Header, error)
+	InvalidBlock(blockHash string) (InvalidBlock, error)
+	InvalidBlockContext(ctx context.Context, blockHash string) (InvalidBlock, error)
+	InvalidBlocks() ([]InvalidBlock, error)
+	InvalidBlocksContext(ctx context.Context) ([]InvalidBlock, error)
+	LiveBlocks(id interface{}) ([]string, error)
+	LiveBlocksContext(ctx context.Context, id interface{}) ([]string, error)
+	Metadata(id interface{}) (*Metadata, error)
+	MetadataContext(ctx context.Context, id interface{}) (*Metadata, error)
+	MonitorHeads(ctx context.Context, input MonitorHeadsInput) (<-chan *Block, <-chan error)
+	NewFollower(input FollowerInput) (*Follower, error)
+	Operation(id interface{}, pass, index int) (*Operations, error)
+	OperationContext(ctx context.Context, id interface{}, pass, index int) (*Operations, error)
+	OperationHashes(blockhash string) ([][]string, error)
+	OperationHashesContext(ctx context.Context, blockhash string) ([][]string, error)
+	OperationsAtPass(id interface{}, pass int) ([]Operations, error)
+	OperationsAtPassContext(ctx context.Context, id interface{}, pass int) ([]Operations, error)
+}
+
+// AccountReader reads the state of accounts and contracts.
+type AccountReader interface {
+	Balance(blockhash, address string) (*big.Int, error)
+	BalanceContext(ctx context.Context, blockhash, address string) (*big.Int, error)
 	ContractStorage(blockhash string, KT1 string) ([]byte, error)
+	ContractStorageContext(ctx context.Context, blockhash string, KT1 string) ([]byte, error)
 	Counter(blockhash, pkh string) (int, error)
+	CounterContext(ctx context.Context, blockhash, pkh string) (int, error)
+}
+
+// DelegateReader reads delegates, their rights and their balances.
+type DelegateReader interface {
+	BakingRights(input BakingRightsInput) (*BakingRights, error)
+	BakingRightsContext(ctx context.Context, input BakingRightsInput) (*BakingRights, error)
 	Cycle(cycle int) (Cycle, error)
+	CycleContext(ctx context.Context, cycle int) (Cycle, error)
 	Delegate(blockhash, delegate string) (Delegate, error)
+	DelegateContext(ctx context.Context, blockhash, delegate string) (Delegate, error)
 	Delegates(input DelegatesInput) ([]*string, error)
+	DelegatesContext(ctx context.Context, input DelegatesInput) ([]*string, error)
 	DelegatedContracts(blockhash, delegate string) ([]*string, error)
+	DelegatedContractsContext(ctx context.Context, blockhash, delegate string) ([]*string, error)
 	DelegatedContractsAtCycle(cycle int, delegate string) ([]*string, error)
-	DeleteInvalidBlock(blockHash string) error
+	DelegatedContractsAtCycleContext(ctx context.Context, cycle int, delegate string) ([]*string, error)
 	EndorsingRights(input EndorsingRightsInput) (*EndorsingRights, error)
+	EndorsingRightsContext(ctx context.Context, input EndorsingRightsInput) (*EndorsingRights, error)
 	FrozenBalance(cycle int, delegate string) (FrozenBalance, error)
-	Head() (*Block, error)
+	FrozenBalanceContext(ctx context.Context, cycle int, delegate string) (FrozenBalance, error)
+	StakingBalance(blockhash, delegate string) (*big.Int, error)
+	StakingBalanceContext(ctx context.Context, blockhash, delegate string) (*big.Int, error)
+	StakingBalanceAtCycle(cycle int, delegate string) (*big.Int, error)
+	StakingBalanceAtCycleContext(ctx context.Context, cycle int, delegate string) (*big.Int, error)
+}
+
+// OperationInjector forges, checks and injects operations, and watches the mempool.
+type OperationInjector interface {
+	ForgeOperationWithRPC(input ForgeOperationWithRPCInput) (string, error)
+	ForgeOperationWithRPCContext(ctx context.Context, input ForgeOperationWithRPCInput) (string, error)
 	InjectionBlock(input InjectionBlockInput) ([]byte, error)
+	InjectionBlockContext(ctx context.Context, input InjectionBlockInput) ([]byte, error)
 	InjectionOperation(input InjectionOperationInput) (string, error)
-	InvalidBlock(blockHash string) (InvalidBlock, error)
-	InvalidBlocks() ([]InvalidBlock, error)
-	OperationHashes(blockhash string) ([][]string, error)
+	InjectionOperationContext(ctx context.Context, input InjectionOperationInput) (string, error)
+	MonitorOperations(ctx context.Context, input MonitorOperationsInput) (<-chan PendingOperation, <-chan error)
+	PendingOperations(filter MempoolFilter) (Mempool, error)
+	PendingOperationsContext(ctx context.Context, filter MempoolFilter) (Mempool, error)
 	PreapplyOperations(input PreapplyOperationsInput) ([]Operations, error)
-	StakingBalance(blockhash, delegate string) (*big.Int, error)
-	StakingBalanceAtCycle(cycle int, delegate string) (*big.Int, error)
+	PreapplyOperationsContext(ctx context.Context, input PreapplyOperationsInput) ([]Operations, error)
+	UnforgeOperationWithRPC(blockhash string, input UnforgeOperationWithRPCInput) ([]Operations, error)
+	UnforgeOperationWithRPCContext(ctx context.Context, blockhash string, input UnforgeOperationWithRPCInput) ([]Operations, error)
+}
+
+// NetworkInspector reads the state of the node, its chains and the network.
+type NetworkInspector interface {
+	ActiveChains() (ActiveChains, error)
+	ActiveChainsContext(ctx context.Context) (ActiveChains, error)
+	Bootstrap() (Bootstrap, error)
+	BootstrapContext(ctx context.Context) (Bootstrap, error)
+	ChainID() (string, error)
+	ChainIDContext(ctx context.Context) (string, error)
+	Checkpoint() (Checkpoint, error)
+	CheckpointContext(ctx context.Context) (Checkpoint, error)
+	Commit() (string, error)
+	CommitContext(ctx context.Context) (string, error)
+	Connections() (Connections, error)
+	ConnectionsContext(ctx context.Context) (Connections, error)
+	Constants(blockhash string) (Constants, error)
+	ConstantsContext(ctx context.Context, blockhash string) (Constants, error)
 	UserActivatedProtocolOverrides() (UserActivatedProtocolOverrides, error)
+	UserActivatedProtocolOverridesContext(ctx context.Context) (UserActivatedProtocolOverrides, error)
 	Version() (Version, error)
+	VersionContext(ctx context.Context) (Version, error)
 }
@@ -2,6 +2,7 @@ package goMXP
 
 import (
 	"net/http/httptest"
+	"reflect"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -17,3 +18,21 @@ func Test_iface(t *testing.T) {
 	assert.Nil(t, err)
 	assert.NotNil(t, gt)
 }
+
+func Test_iface_coverage(t *testing.T) {
+	iface := reflect.TypeOf((*IFace)(nil)).Elem()
+	gt := reflect.TypeOf(&GoMXP{})
+
+	for i := 0; i < gt.NumMethod(); i++ {
+		method := gt.Method(i)
+		_, ok := iface.MethodByName(method.Name)
+		assert.True(t, ok, "IFace is missing GoMXP.%s", method.Name)
+	}
+
+	var _ BlockReader = &GoMXP{}
+	var _ AccountReader = &GoMXP{}
+	var _ DelegateReader = &GoMXP{}
+	var _ OperationInjector = &GoMXP{}
+	var _ NetworkInspector = &GoMXP{}
+	var _ IFace = &Pool{}
+}
@@ -0,0 +1,249 @@
+package goMXP
+
+import (
+	"math/big"
+
+	"github.com/pkg/errors"
+)
+
+// LedgerCategory is the category of a balance change in a Ledger.
+type LedgerCategory string
+
+const (
+	// LedgerContract is a change of the spendable balance of a contract
+	LedgerContract LedgerCategory = "contract"
+	// LedgerFees is a change of the frozen fees of a delegate
+	LedgerFees LedgerCategory = "fees"
+	// LedgerRewards is a change of the frozen rewards of a delegate
+	LedgerRewards LedgerCategory = "rewards"
+	// LedgerDeposits is a change of the frozen deposits of a delegate
+	LedgerDeposits LedgerCategory = "deposits"
+	// LedgerStorageBurn is a debit of a contract burned to pay for storage
+	LedgerStorageBurn LedgerCategory = "storage_burn"
+	// LedgerAllocationBurn is a debit of a contract burned to allocate a new contract
+	LedgerAllocationBurn LedgerCategory = "allocation_burn"
+)
+
+/*
+LedgerEntry is a single balance change of a block.
+
+Address is the contract of a contract change or the delegate of a frozen balance change, and Cycle
+the cycle of a frozen balance change. Operation is the operation the change comes from, or nil for
+the changes of the block itself (baking deposits and rewards). Kind is the kind of the operation
+contents, or of the internal operation when Internal is true, in which case Nonce is its nonce.
+*/
+type LedgerEntry struct {
+	Address   string
+	Category  LedgerCategory
+	Change    *big.Int
+	Cycle     int
+	Operation *BlockOperation
+	Kind      string
+	Internal  bool
+	Nonce     uint64
+}
+
+/*
+Ledger is every balance change of a block, in the order the node reported them.
+*/
+type Ledger struct {
+	Entries []LedgerEntry
+}
+
+// Address returns the entries of the ledger for address.
+func (l *Ledger) Address(address string) []LedgerEntry {
+	var entries []LedgerEntry
+	for _, entry := range l.Entries {
+		if entry.Address == address {
+			entries = append(entries, entry)
+		}
+	}
+
+	return entries
+}
+
+// Totals returns the net change of the ledger per address and category.
+func (l *Ledger) Totals() map[string]map[LedgerCategory]*big.Int {
+	totals := map[string]map[LedgerCategory]*big.Int{}
+	for _, entry := range l.Entries {
+		categories, ok := totals[entry.Address]
+		if !ok {
+			categories = map[LedgerCategory]*big.Int{}
+			totals[entry.Address] = categories
+		}
+
+		total, ok := categories[entry.Category]
+		if !ok {
+			total = big.NewInt(0)
+			categories[entry.Category] = total
+		}
+		total.Add(total, entry.Change)
+	}
+
+	return totals
+}
+
+// Net returns the sum of every change of the ledger: the rewards created by the block minus the burns.
+func (l *Ledger) Net() *big.Int {
+	net := big.NewInt(0)
+	for _, entry := range l.Entries {
+		net.Add(net, entry.Change)
+	}
+
+	return net
+}
+
+/*
+Ledger gathers the balance updates of the block, of the contents of its operations, of their results
+and of their internal operation results into a single Ledger. Updates of results that were not applied
+are left out, as they did not move any funds.
+
+Burns are told apart from transfers with the cost per byte and origination size of constants. An
+update that is neither part of a transfer nor a burn the result accounts for returns an error.
+
+Parameters:
+
+	constants:
+		The constants of the cycle of the block.
+*/
+func (b *Block) Ledger(constants Constants) (*Ledger, error) {
+	ledger := &Ledger{}
+	ledger.add(b.Metadata.BalanceUpdates, nil, nil, "", false, 0)
+
+	for pass, operations := range b.Operations {
+		for index, operation := range operations {
+			for i, c := range operation.Contents {
+				if c.Metadata == nil {
+					continue
+				}
+
+				op := &BlockOperation{Hash: operation.Hash, Pass: pass, Index: index, ContentsIndex: i, Status: "applied"}
+				ledger.add(c.Metadata.BalanceUpdates, nil, op, c.Kind, false, 0)
+
+				if result := c.Metadata.OperationResult; result != nil {
+					op.Status = result.Status
+					op.Errors = result.Errors
+					if err := ledger.addResult(result, constants, c.Source, op, c.Kind, false, 0); err != nil {
+						return nil, errors.Wrapf(err, "failed to build ledger of block '%s'", b.Hash)
+					}
+				}
+
+				for _, internal := range c.Metadata.InternalOperationResults {
+					if internal == nil || internal.Result == nil {
+						continue
+					}
+
+					if err := ledger.addResult(internal.Result, constants, c.Source, op, internal.Kind, true, internal.Nonce); err != nil {
+						return nil, errors.Wrapf(err, "failed to build ledger of block '%s'", b.Hash)
+					}
+				}
+			}
+		}
+	}
+
+	return ledger, nil
+}
+
+func (l *Ledger) add(updates []BalanceUpdates, categories []LedgerCategory, op *BlockOperation, kind string, internal bool, nonce uint64) {
+	for i, update := range updates {
+		entry := LedgerEntry{
+			Address:   update.Contract,
+			Category:  LedgerContract,
+			Change:    big.NewInt(0),
+			Cycle:     update.Cycle,
+			Operation: op,
+			Kind:      kind,
+			Internal:  internal,
+			Nonce:     nonce,
+		}
+
+		if update.Kind == "freezer" {
+			entry.Address = update.Delegate
+			entry.Category = LedgerCategory(update.Category)
+		}
+
+		if categories != nil {
+			entry.Category = categories[i]
+		}
+
+		if update.Change != nil && update.Change.Big != nil {
+			entry.Change.Set(update.Change.Big)
+		}
+
+		l.Entries = append(l.Entries, entry)
+	}
+}
+
+func (l *Ledger) addResult(result *OperationResult, constants Constants, payer string, op *BlockOperation, kind string, internal bool, nonce uint64) error {
+	if result.Status != "applied" {
+		return nil
+	}
+
+	categories, err := classifyResult(result, constants, payer)
+	if err != nil {
+		return errors.Wrapf(err, "failed to classify balance updates of operation '%s'", op.Hash)
+	}
+
+	l.add(result.BalanceUpdates, categories, op, kind, internal, nonce)
+	return nil
+}
+
+// classifyResult tells the transfers of the balance updates of result, which are a debit directly followed
+// by a credit of the same amount, apart from the burns paid by payer.
+func classifyResult(result *OperationResult, constants Constants, payer string) ([]LedgerCategory, error) {
+	costPerByte := big.NewInt(0)
+	if constants.CostPerByte != nil && constants.CostPerByte.Big != nil {
+		costPerByte.Set(constants.CostPerByte.Big)
+	}
+
+	allocations := len(result.OriginatedContracts)
+	if result.AllocatedDestinationContract {
+		allocations++
+	}
+	allocation := new(big.Int).Mul(big.NewInt(int64(constants.OriginationSize)), costPerByte)
+
+	storage := big.NewInt(0)
+	if result.PaidStorageSizeDiff != nil && result.PaidStorageSizeDiff.Big != nil {
+		storage.Mul(result.PaidStorageSizeDiff.Big, costPerByte)
+	}
+
+	updates := result.BalanceUpdates
+	categories := make([]LedgerCategory, len(updates))
+	for i := 0; i < len(updates); i++ {
+		if updates[i].Kind == "freezer" {
+			categories[i] = LedgerCategory(updates[i].Category)
+			continue
+		}
+
+		change := big.NewInt(0)
+		if updates[i].Change != nil && updates[i].Change.Big != nil {
+			change.Set(updates[i].Change.Big)
+		}
+
+		if change.Sign() < 0 && i+1 < len(updates) && updates[i+1].Kind == "contract" && updates[i+1].Change != nil &&
+			updates[i+1].Change.Big != nil && new(big.Int).Add(change, updates[i+1].Change.Big).Sign() == 0 {
+			categories[i], categories[i+1] = LedgerContract, LedgerContract
+			i++
+			continue
+		}
+
+		if updates[i].Contract == payer && change.Sign() < 0 {
+			burned := new(big.Int).Neg(change)
+			if allocations > 0 && burned.Cmp(allocation) == 0 {
+				categories[i] = LedgerAllocationBurn
+				allocations--
+				continue
+			}
+
+			if storage.Sign() > 0 && burned.Cmp(storage) == 0 {
+				categories[i] = LedgerStorageBurn
+				storage.SetInt64(0)
+				continue
+			}
+		}
+
+		return nil, errors.Errorf("balance update of '%s' by %s is neither a transfer nor a burn", updates[i].Contract, change)
+	}
+
+	return categories, nil
+}
@@ -0,0 +1,168 @@
+package goMXP
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Block_Ledger(t *testing.T) {
+	goldenBlock := getResponse(block).(*Block)
+	goldenConstants := getResponse(constants).(Constants)
+
+	ledger, err := goldenBlock.Ledger(goldenConstants)
+	assert.Nil(t, err)
+	assert.Len(t, ledger.Entries, 1569)
+	assert.Equal(t, "67886000", ledger.Net().String())
+
+	baker := ledger.Address("tz3adcvQaKXTCg12zbninqo3q8ptKKtDFTLv")
+	assert.Nil(t, baker[0].Operation)
+	assert.Equal(t, LedgerEntry{
+		Address:  "tz3adcvQaKXTCg12zbninqo3q8ptKKtDFTLv",
+		Category: LedgerDeposits,
+		Change:   big.NewInt(512000000),
+		Cycle:    205,
+	}, baker[1])
+
+	totals := ledger.Totals()
+	assert.Equal(t, map[LedgerCategory]*big.Int{
+		LedgerContract: big.NewInt(-64000000),
+		LedgerDeposits: big.NewInt(64000000),
+		LedgerRewards:  big.NewInt(2000000),
+	}, totals["tz1NpWrAyDL9k2Lmnyxcgr9xuJakbBxdq7FB"])
+
+	var allocator []LedgerEntry
+	for _, entry := range ledger.Address("tz1SiPXX4MYGNJNDsRc7n8hkvUqFzg8xqF9m") {
+		if entry.Operation.ContentsIndex == 2 {
+			allocator = append(allocator, entry)
+		}
+	}
+	if assert.Len(t, allocator, 3) {
+		assert.Equal(t, LedgerContract, allocator[0].Category)
+		assert.Equal(t, big.NewInt(-2940), allocator[0].Change)
+		assert.Equal(t, LedgerContract, allocator[1].Category)
+		assert.Equal(t, big.NewInt(-8439966), allocator[1].Change)
+		assert.Equal(t, LedgerAllocationBurn, allocator[2].Category)
+		assert.Equal(t, big.NewInt(-257000), allocator[2].Change)
+		assert.Equal(t, "opP3ZdaUB2BqY5etBgKXWqfYSDh2roAeFPCNghX1HKzQ2h2xpQq", allocator[2].Operation.Hash)
+		assert.Equal(t, TRANSACTIONOP, allocator[2].Kind)
+	}
+
+	backtracked := ledger.Address("tz1Q8QkSBS63ZQnH3fBTiAMPes9R666Rn6Sc")
+	assert.Len(t, backtracked, 28)
+	assert.Equal(t, "backtracked", backtracked[18].Operation.Status)
+	assert.Equal(t, "skipped", backtracked[19].Operation.Status)
+	assert.Equal(t, map[LedgerCategory]*big.Int{LedgerContract: big.NewInt(-28 * 1792)}, totals["tz1Q8QkSBS63ZQnH3fBTiAMPes9R666Rn6Sc"])
+}
+
+func Test_Block_Ledger_burns(t *testing.T) {
+	goldenConstants := getResponse(constants).(Constants)
+
+	update := func(contract string, change int) BalanceUpdates {
+		return BalanceUpdates{Kind: "contract", Contract: contract, Change: NewInt(change)}
+	}
+
+	origination := func(result *OperationResult, internal ...*InternalOperationResults) *Block {
+		return &Block{
+			Hash: "BLock",
+			Operations: [][]Operations{{}, {}, {}, {
+				{
+					Hash: "oOrigination",
+					Contents: []Contents{
+						{Kind: ORIGINATIONOP, Source: "tz1a", Metadata: &ContentsMetadata{OperationResult: result, InternalOperationResults: internal}},
+					},
+				},
+			}},
+		}
+	}
+
+	type want struct {
+		err         bool
+		errContains string
+		categories  []LedgerCategory
+	}
+
+	cases := []struct {
+		name  string
+		input *Block
+		want  want
+	}{
+		{
+			"is successful with storage and allocation burns",
+			origination(&OperationResult{
+				Status:              "applied",
+				OriginatedContracts: []string{"KT1a"},
+				PaidStorageSizeDiff: NewInt(1641),
+				BalanceUpdates:      []BalanceUpdates{update("tz1a", -1641000), update("tz1a", -257000), update("tz1a", -1000000), update("KT1a", 1000000)},
+			}),
+			want{false, "", []LedgerCategory{LedgerStorageBurn, LedgerAllocationBurn, LedgerContract, LedgerContract}},
+		},
+		{
+			"is successful with internal operations",
+			origination(
+				&OperationResult{Status: "applied"},
+				&InternalOperationResults{
+					Kind:   TRANSACTIONOP,
+					Nonce:  1,
+					Result: &OperationResult{Status: "applied", AllocatedDestinationContract: true, BalanceUpdates: []BalanceUpdates{update("KT1a", -5), update("tz1b", 5), update("tz1a", -257000)}},
+				},
+				&InternalOperationResults{Kind: TRANSACTIONOP, Nonce: 2, Result: &OperationResult{Status: "failed", BalanceUpdates: []BalanceUpdates{update("KT1a", -5), update("tz1c", 5)}}},
+			),
+			want{false, "", []LedgerCategory{LedgerContract, LedgerContract, LedgerAllocationBurn}},
+		},
+		{
+			"handles unexplained debits",
+			origination(&OperationResult{Status: "applied", BalanceUpdates: []BalanceUpdates{update("tz1a", -257000)}}),
+			want{true, "failed to build ledger of block 'BLock': failed to classify balance updates of operation 'oOrigination': balance update of 'tz1a' by -257000 is neither a transfer nor a burn", nil},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			ledger, err := tt.input.Ledger(goldenConstants)
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+
+			var categories []LedgerCategory
+			if ledger != nil {
+				for _, entry := range ledger.Entries {
+					categories = append(categories, entry.Category)
+				}
+			}
+			assert.Equal(t, tt.want.categories, categories)
+		})
+	}
+}
+
+func Test_Block_Ledger_internal(t *testing.T) {
+	b := &Block{
+		Operations: [][]Operations{{}, {}, {}, {
+			{
+				Hash: "oCall",
+				Contents: []Contents{{
+					Kind:   TRANSACTIONOP,
+					Source: "tz1a",
+					Metadata: &ContentsMetadata{
+						OperationResult: &OperationResult{Status: "applied"},
+						InternalOperationResults: []*InternalOperationResults{
+							{Kind: TRANSACTIONOP, Nonce: 3, Result: &OperationResult{Status: "applied", BalanceUpdates: []BalanceUpdates{
+								{Kind: "contract", Contract: "KT1a", Change: NewInt(-5)},
+								{Kind: "contract", Contract: "tz1b", Change: NewInt(5)},
+							}}},
+						},
+					},
+				}},
+			},
+		}},
+	}
+
+	ledger, err := b.Ledger(Constants{})
+	assert.Nil(t, err)
+	if assert.Len(t, ledger.Entries, 2) {
+		assert.True(t, ledger.Entries[1].Internal)
+		assert.Equal(t, uint64(3), ledger.Entries[1].Nonce)
+		assert.Equal(t, "oCall", ledger.Entries[1].Operation.Hash)
+		assert.Equal(t, "tz1b", ledger.Entries[1].Address)
+	}
+	assert.Equal(t, "0", ledger.Net().String())
+}
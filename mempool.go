@@ -0,0 +1,322 @@
+package goMXP
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+Mempool represents the pending operations of a node's mempool.
+
+RPC:
+	/chains/<chain_id>/mempool/pending_operations (GET)
+
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-chains-chain-id-mempool-pending-operations
+*/
+type Mempool struct {
+	Applied       []PendingOperation
+	Refused       []PendingOperation
+	BranchRefused []PendingOperation
+	BranchDelayed []PendingOperation
+	Unprocessed   []PendingOperation
+}
+
+/*
+PendingOperation represents an operation that is not included in a block yet. Errors holds the
+reason the node refused or delayed the operation.
+
+RPC:
+	/chains/<chain_id>/mempool/pending_operations (GET)
+	/chains/<chain_id>/mempool/monitor_operations (GET)
+
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-chains-chain-id-mempool-pending-operations
+*/
+type PendingOperation struct {
+	Operations
+	Errors RPCErrors `json:"error,omitempty"`
+}
+
+/*
+UnmarshalJSON implements the json.Unmarshaler interface for Mempool. Applied operations are
+listed as objects, while the other classifications are listed as [hash, operation] pairs.
+
+Parameters:
+
+	b:
+		The byte representation of a Mempool.
+*/
+func (m *Mempool) UnmarshalJSON(b []byte) error {
+	var mempool struct {
+		Applied       []PendingOperation `json:"applied"`
+		Refused       []json.RawMessage  `json:"refused"`
+		BranchRefused []json.RawMessage  `json:"branch_refused"`
+		BranchDelayed []json.RawMessage  `json:"branch_delayed"`
+		Unprocessed   []json.RawMessage  `json:"unprocessed"`
+	}
+
+	err := json.Unmarshal(b, &mempool)
+	if err != nil {
+		return err
+	}
+
+	m.Applied = mempool.Applied
+	for _, classification := range []struct {
+		dst   *[]PendingOperation
+		pairs []json.RawMessage
+	}{
+		{&m.Refused, mempool.Refused},
+		{&m.BranchRefused, mempool.BranchRefused},
+		{&m.BranchDelayed, mempool.BranchDelayed},
+		{&m.Unprocessed, mempool.Unprocessed},
+	} {
+		*classification.dst, err = unmarshalPendingOperationPairs(classification.pairs)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func unmarshalPendingOperationPairs(pairs []json.RawMessage) ([]PendingOperation, error) {
+	var operations []PendingOperation
+	for _, pair := range pairs {
+		var raw []json.RawMessage
+		err := json.Unmarshal(pair, &raw)
+		if err != nil {
+			return operations, err
+		}
+
+		if len(raw) != 2 {
+			return operations, errors.Errorf("expected a [hash, operation] pair but got %d elements", len(raw))
+		}
+
+		var operation PendingOperation
+		if err := json.Unmarshal(raw[0], &operation.Hash); err != nil {
+			return operations, err
+		}
+
+		hash := operation.Hash
+		if err := json.Unmarshal(raw[1], &operation); err != nil {
+			return operations, err
+		}
+
+		if operation.Hash == "" {
+			operation.Hash = hash
+		}
+
+		operations = append(operations, operation)
+	}
+
+	return operations, nil
+}
+
+/*
+MempoolFilter selects pending operations. An operation is selected if any of its contents match
+every filter that is set.
+*/
+type MempoolFilter struct {
+	// Only select operations with contents from one of these sources.
+	Sources []string
+
+	// Only select operations with contents to one of these destinations.
+	Destinations []string
+
+	// Only select operations with contents of one of these kinds (e.g. transaction).
+	Kinds []string
+}
+
+func (f *MempoolFilter) match(operation PendingOperation) bool {
+	if len(f.Sources) == 0 && len(f.Destinations) == 0 && len(f.Kinds) == 0 {
+		return true
+	}
+
+	for _, contents := range operation.Contents {
+		if matchAny(f.Sources, contents.Source) && matchAny(f.Destinations, contents.Destination) && matchAny(f.Kinds, contents.Kind) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (f *MempoolFilter) filter(operations []PendingOperation) []PendingOperation {
+	var filtered []PendingOperation
+	for _, operation := range operations {
+		if f.match(operation) {
+			filtered = append(filtered, operation)
+		}
+	}
+
+	return filtered
+}
+
+func matchAny(values []string, value string) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+PendingOperations gets the operations in the mempool of the node, by classification.
+
+Path:
+	/chains/<chain_id>/mempool/pending_operations (GET)
+
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-chains-chain-id-mempool-pending-operations
+
+Parameters:
+
+	filter:
+		Selects the operations to return.
+*/
+func (t *GoMXP) PendingOperations(filter MempoolFilter) (Mempool, error) {
+	return t.PendingOperationsContext(context.Background(), filter)
+}
+
+// PendingOperationsContext is PendingOperations bound to ctx for cancellation and deadlines.
+func (t *GoMXP) PendingOperationsContext(ctx context.Context, filter MempoolFilter) (Mempool, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/mempool/pending_operations", t.chainPath(ctx)))
+	if err != nil {
+		return Mempool{}, errors.Wrap(err, "failed to get pending operations")
+	}
+
+	var mempool Mempool
+	err = json.Unmarshal(resp, &mempool)
+	if err != nil {
+		return Mempool{}, errors.Wrap(err, "failed to unmarshal pending operations")
+	}
+
+	return Mempool{
+		Applied:       filter.filter(mempool.Applied),
+		Refused:       filter.filter(mempool.Refused),
+		BranchRefused: filter.filter(mempool.BranchRefused),
+		BranchDelayed: filter.filter(mempool.BranchDelayed),
+		Unprocessed:   filter.filter(mempool.Unprocessed),
+	}, nil
+}
+
+/*
+MonitorOperationsInput is the input for the goMXP.MonitorOperations function.
+
+Function:
+	func (t *GoMXP) MonitorOperations(ctx context.Context, input MonitorOperationsInput) (<-chan PendingOperation, <-chan error) {}
+*/
+type MonitorOperationsInput struct {
+	// Monitor applied operations. The node monitors them by default when no classification is set.
+	Applied bool
+
+	// Monitor refused operations.
+	Refused bool
+
+	// Monitor branch refused operations.
+	BranchRefused bool
+
+	// Monitor branch delayed operations.
+	BranchDelayed bool
+
+	// Selects the operations to deliver.
+	Filter MempoolFilter
+
+	// How long to wait before reconnecting after the stream fails. Defaults to 1s.
+	ReconnectDelay time.Duration
+}
+
+func (m *MonitorOperationsInput) contructRPCOptions() []rpcOptions {
+	if !m.Applied && !m.Refused && !m.BranchRefused && !m.BranchDelayed {
+		return nil
+	}
+
+	return []rpcOptions{
+		{"applied", strconv.FormatBool(m.Applied)},
+		{"refused", strconv.FormatBool(m.Refused)},
+		{"branch_refused", strconv.FormatBool(m.BranchRefused)},
+		{"branch_delayed", strconv.FormatBool(m.BranchDelayed)},
+	}
+}
+
+/*
+MonitorOperations keeps a streaming connection open to the node and delivers the operations that
+enter its mempool. The node ends the stream whenever its mempool is flushed for a new head, after
+which it is reopened immediately; operations the node sends again on the new stream are not delivered
+twice. After a failure the stream is reopened after ReconnectDelay.
+
+Errors are sent on the error channel. It is buffered and errors are dropped when it is full, so it
+never stalls the stream. Both channels are closed once ctx is done.
+
+Path:
+	/chains/<chain_id>/mempool/monitor_operations (GET)
+
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-chains-chain-id-mempool-monitor-operations
+
+Parameters:
+
+	ctx:
+		Cancel ctx to close the stream.
+
+	input:
+		Modifies the MonitorOperations stream.
+*/
+func (t *GoMXP) MonitorOperations(ctx context.Context, input MonitorOperationsInput) (<-chan PendingOperation, <-chan error) {
+	operations := make(chan PendingOperation)
+	errs := make(chan error, 10)
+
+	go func() {
+		defer close(operations)
+		defer close(errs)
+
+		// Operations are remembered for the current and the previous stream, which is all the node re-sends.
+		var previous, current map[string]bool
+		t.follow(ctx, streamer{
+			path:   fmt.Sprintf("%s/mempool/monitor_operations", t.chainPath(ctx)),
+			opts:   input.contructRPCOptions(),
+			delay:  input.ReconnectDelay,
+			resume: true,
+			connect: func() {
+				previous, current = current, map[string]bool{}
+			},
+			chunk: func(chunk json.RawMessage) error {
+				var batch []PendingOperation
+				err := json.Unmarshal(chunk, &batch)
+				if err != nil {
+					return errors.Wrap(err, "could not unmarshal operations")
+				}
+
+				for _, operation := range input.Filter.filter(batch) {
+					seen := current[operation.Hash] || previous[operation.Hash]
+					current[operation.Hash] = true
+					if seen {
+						continue
+					}
+
+					select {
+					case operations <- operation:
+					case <-ctx.Done():
+						return ctx.Err()
+					}
+				}
+
+				return nil
+			},
+		}, errs)
+	}()
+
+	return operations, errs
+}
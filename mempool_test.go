@@ -0,0 +1,175 @@
+package goMXP
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_PendingOperations(t *testing.T) {
+	goldenMempool := getResponse(pendingOperations).(Mempool)
+
+	type want struct {
+		err         bool
+		errContains string
+		hashes      [][]string
+	}
+
+	cases := []struct {
+		name   string
+		input  http.Handler
+		filter MempoolFilter
+		want   want
+	}{
+		{
+			"returns rpc error",
+			gtGoldenHTTPMock(pendingOperationsHandlerMock(readResponse(rpcerrors), blankHandler)),
+			MempoolFilter{},
+			want{true, "failed to get pending operations", nil},
+		},
+		{
+			"fails to unmarshal",
+			gtGoldenHTTPMock(pendingOperationsHandlerMock([]byte(`{"refused":[["hash"]]}`), blankHandler)),
+			MempoolFilter{},
+			want{true, "failed to unmarshal pending operations", nil},
+		},
+		{
+			"is successful",
+			gtGoldenHTTPMock(pendingOperationsHandlerMock(readResponse(pendingOperations), blankHandler)),
+			MempoolFilter{},
+			want{false, "", [][]string{
+				{"ooYSSxYcgreJQtrKAjBTTFkRsBrBh3cVJyBvGhcXVGd6sWujVWw", "opNfjjEfWk7HyGUWCezsnvjrqzFk7sm6BhqTs6QD6aMYZdDmtFb"},
+				{"onvZ1iqbQV5tKfbkq2S4UA1uXm7YqM6jhXaxLHJMMWUu5XhsWnn"},
+				nil,
+				{"ooBghN2ok5EpgEuMqYWqvfwNLBiK9eNFoPai91iwqk2nRCyUKgE"},
+				{"oo2XPsmWZbovCXGuUpwRzE9xUvoLXT8CmQxBaBr6CE1vPmLFrzk"},
+			}},
+		},
+		{
+			"filters by source",
+			gtGoldenHTTPMock(pendingOperationsHandlerMock(readResponse(pendingOperations), blankHandler)),
+			MempoolFilter{Sources: []string{"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"}},
+			want{false, "", [][]string{
+				{"ooYSSxYcgreJQtrKAjBTTFkRsBrBh3cVJyBvGhcXVGd6sWujVWw"},
+				{"onvZ1iqbQV5tKfbkq2S4UA1uXm7YqM6jhXaxLHJMMWUu5XhsWnn"},
+				nil,
+				nil,
+				nil,
+			}},
+		},
+		{
+			"filters by destination and kind",
+			gtGoldenHTTPMock(pendingOperationsHandlerMock(readResponse(pendingOperations), blankHandler)),
+			MempoolFilter{Destinations: []string{"tz1Z3JYUsqCUwCBwqFWNm9oxAXFaiQ8NDkFx"}, Kinds: []string{"transaction", "delegation"}},
+			want{false, "", [][]string{
+				{"ooYSSxYcgreJQtrKAjBTTFkRsBrBh3cVJyBvGhcXVGd6sWujVWw"},
+				{"onvZ1iqbQV5tKfbkq2S4UA1uXm7YqM6jhXaxLHJMMWUu5XhsWnn"},
+				nil,
+				nil,
+				nil,
+			}},
+		},
+		{
+			"filters by kind",
+			gtGoldenHTTPMock(pendingOperationsHandlerMock(readResponse(pendingOperations), blankHandler)),
+			MempoolFilter{Kinds: []string{"endorsement", "reveal"}},
+			want{false, "", [][]string{
+				{"opNfjjEfWk7HyGUWCezsnvjrqzFk7sm6BhqTs6QD6aMYZdDmtFb"},
+				nil,
+				nil,
+				nil,
+				{"oo2XPsmWZbovCXGuUpwRzE9xUvoLXT8CmQxBaBr6CE1vPmLFrzk"},
+			}},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(tt.input)
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+
+			mempool, err := gt.PendingOperations(tt.filter)
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+
+			var hashes [][]string
+			if tt.want.hashes != nil {
+				for _, classification := range [][]PendingOperation{mempool.Applied, mempool.Refused, mempool.BranchRefused, mempool.BranchDelayed, mempool.Unprocessed} {
+					var h []string
+					for _, operation := range classification {
+						h = append(h, operation.Hash)
+					}
+					hashes = append(hashes, h)
+				}
+			}
+			assert.Equal(t, tt.want.hashes, hashes)
+		})
+	}
+
+	t.Run("decodes refused operations", func(t *testing.T) {
+		refused := goldenMempool.Refused[0]
+		assert.Equal(t, "PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS", refused.Protocol)
+		assert.Equal(t, "9", refused.Contents[0].Counter.Big.String())
+		assert.Len(t, refused.Errors, 1)
+		assert.True(t, refused.Errors[0].HasID("counter_in_the_past"))
+		assert.Equal(t, "11", refused.Errors[0].Expected.Big.String())
+	})
+}
+
+var regMonitorOperations = regexp.MustCompile(`\/chains\/main\/mempool\/monitor_operations`)
+
+func mockPendingOperation(hash, kind string) string {
+	return fmt.Sprintf(`{"hash":"%s","protocol":"PsBabyM1eUXZseaJdmXFApDSBqj8YBfwELoxZHHW77EMcAbbwAS","branch":"BLfEWKVudXH15N8nwHZehyLNjRuNLoJavJDjSZ7nq8ggfzbZ18p","contents":[{"kind":"%s","source":"tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc"}],"signature":"sig"}`, hash, kind)
+}
+
+func Test_MonitorOperations(t *testing.T) {
+	var count int32
+	var query atomic.Value
+	server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !regMonitorOperations.MatchString(r.URL.String()) {
+			return
+		}
+		query.Store(r.URL.RawQuery)
+
+		switch atomic.AddInt32(&count, 1) {
+		case 1:
+			fmt.Fprintf(w, "[%s,%s]\n", mockPendingOperation("ooA", "transaction"), mockPendingOperation("ooB", "endorsement"))
+			fmt.Fprintf(w, "[%s]\n", mockPendingOperation("ooC", "transaction"))
+		default:
+			fmt.Fprintf(w, "[%s,%s]\n", mockPendingOperation("ooC", "transaction"), mockPendingOperation("ooD", "transaction"))
+			w.(http.Flusher).Flush()
+			<-r.Context().Done()
+		}
+	})))
+	defer server.Close()
+
+	gt, err := New(server.URL)
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	operations, errs := gt.MonitorOperations(ctx, MonitorOperationsInput{
+		Applied: true,
+		Refused: true,
+		Filter:  MempoolFilter{Kinds: []string{"transaction"}},
+	})
+
+	var hashes []string
+	for i := 0; i < 3; i++ {
+		hashes = append(hashes, (<-operations).Hash)
+	}
+	assert.Equal(t, []string{"ooA", "ooC", "ooD"}, hashes)
+	assert.Equal(t, "applied=true&branch_delayed=false&branch_refused=false&refused=true", query.Load())
+
+	cancel()
+	_, ok := <-operations
+	assert.False(t, ok)
+	assert.Len(t, errs, 0)
+}
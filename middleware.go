@@ -0,0 +1,132 @@
+package goMXP
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+/*
+RPCCall is a single request GoMXP sends to the node, as seen by Middleware. The fields describing
+the outcome are set once the next handler in the chain returns. Every retry of a request is a
+separate RPCCall. Streaming RPCs (e.g. MonitorHeads) and responses served from the cache (see
+SetCache) do not go through the middleware chain.
+*/
+type RPCCall struct {
+	// The request. Middleware may replace it, for example to add headers or a traced context.
+	Request *http.Request
+
+	// The attempt of the request the call is, starting at 1. Only a RetryPolicy makes further attempts.
+	Attempt int
+
+	// The path of the request with its parameters replaced by placeholders
+	// (e.g. /chains/{chain}/blocks/{block}/context/contracts/{id}/balance).
+	Template string
+
+	// The response, with its body already read and closed. Nil if no response was received.
+	Response *http.Response
+
+	// The HTTP status of the response. 0 if no response was received.
+	StatusCode int
+
+	// The body of the response.
+	Body []byte
+
+	// The size of the body of the response in bytes.
+	Size int
+
+	// How long it took to send the request and read the response.
+	Latency time.Duration
+
+	// The decoded error of the call (usually a *RequestError), or nil if the call succeeded.
+	Err error
+}
+
+/*
+RPCHandler sends an RPCCall and records its outcome on it.
+*/
+type RPCHandler func(call *RPCCall)
+
+/*
+Middleware wraps the RPCHandler of a GoMXP to observe or modify calls, for example for logging,
+metrics or tracing. A Middleware must call next to send the call.
+*/
+type Middleware func(next RPCHandler) RPCHandler
+
+/*
+Use appends middleware to GoMXP's middleware chain. The first middleware added is the outermost,
+and sees calls first and their outcome last.
+
+Parameters:
+
+	middleware:
+		The middleware to add.
+*/
+func (t *GoMXP) Use(middleware ...Middleware) {
+	t.Configure(WithMiddleware(middleware...))
+}
+
+/*
+WithMiddleware adds middleware to the GoMXP's middleware chain. See GoMXP.Use.
+
+Parameters:
+
+	middleware:
+		The middleware to add.
+*/
+func WithMiddleware(middleware ...Middleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, middleware...)
+	}
+}
+
+// pathParams maps a path segment to the placeholder of the parameter that follows it.
+var pathParams = map[string]string{
+	"chains":           "{chain}",
+	"blocks":           "{block}",
+	"contracts":        "{id}",
+	"index":            "{id}",
+	"delegates":        "{pkh}",
+	"invalid_blocks":   "{block_hash}",
+	"cycle":            "{cycle}",
+	"frozen_balance":   "{cycle}",
+	"heads":            "{chain}",
+	"operations":       "{pass}",
+	"operation_hashes": "{pass}",
+}
+
+// pathTemplate replaces the parameters in path with placeholders.
+func pathTemplate(path string) string {
+	segments := strings.Split(path, "/")
+	template := make([]string, len(segments))
+	copy(template, segments)
+
+	for i := 1; i < len(segments); i++ {
+		param, ok := pathParams[segments[i-1]]
+		if !ok || segments[i] == "" {
+			continue
+		}
+
+		switch param {
+		case "{id}":
+			if segments[i] == "index" {
+				continue
+			}
+		case "{pass}":
+			if _, err := strconv.Atoi(segments[i]); err != nil {
+				continue
+			}
+
+			if i+1 < len(segments) {
+				if _, err := strconv.Atoi(segments[i+1]); err == nil {
+					template[i+1] = "{index}"
+				}
+			}
+		}
+
+		template[i] = param
+	}
+
+	return strings.Join(template, "/")
+}
@@ -0,0 +1,98 @@
+package goMXP
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Use(t *testing.T) {
+	server := httptest.NewServer(balanceHandlerMock(readResponse(balance), counterHandlerMock(readResponse(rpcerrors), http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Trace") != "" {
+			w.Header().Set("X-Trace", r.Header.Get("X-Trace"))
+		}
+		w.Write(readResponse(chainid))
+	}))))
+	defer server.Close()
+
+	var order []string
+	var calls []RPCCall
+	record := func(next RPCHandler) RPCHandler {
+		return func(call *RPCCall) {
+			order = append(order, "record")
+			next(call)
+			calls = append(calls, *call)
+		}
+	}
+	trace := func(next RPCHandler) RPCHandler {
+		return func(call *RPCCall) {
+			order = append(order, "trace")
+			call.Request = call.Request.Clone(call.Request.Context())
+			call.Request.Header.Set("X-Trace", "span")
+			next(call)
+		}
+	}
+
+	gt, err := New(server.URL, WithLazyConstants(), WithMiddleware(record))
+	assert.Nil(t, err)
+	gt.Use(trace)
+
+	_, err = gt.Balance(mockBlockHash, mockAddressTz1)
+	assert.Nil(t, err)
+
+	_, err = gt.Counter(mockBlockHash, mockAddressTz1)
+	checkErr(t, true, "rpc error (somekind)", err)
+
+	_, err = gt.ChainID()
+	assert.Nil(t, err)
+
+	assert.Equal(t, []string{"record", "trace", "record", "trace", "record", "trace"}, order)
+	assert.Len(t, calls, 3)
+
+	assert.Equal(t, "/chains/{chain}/blocks/{block}/context/contracts/{id}/balance", calls[0].Template)
+	assert.Equal(t, http.StatusOK, calls[0].StatusCode)
+	assert.Equal(t, readResponse(balance), calls[0].Body)
+	assert.Equal(t, len(readResponse(balance)), calls[0].Size)
+	assert.True(t, calls[0].Latency > 0)
+	assert.Nil(t, calls[0].Err)
+
+	assert.Equal(t, "/chains/{chain}/blocks/{block}/context/contracts/{id}/counter", calls[1].Template)
+	var reqErr *RequestError
+	assert.True(t, errors.As(calls[1].Err, &reqErr))
+	assert.Equal(t, "somekind", reqErr.Errors[0].Kind)
+
+	assert.Equal(t, "/chains/{chain}/chain_id", calls[2].Template)
+	assert.Equal(t, "span", calls[2].Response.Header.Get("X-Trace"))
+}
+
+func Test_pathTemplate(t *testing.T) {
+	cases := []struct {
+		path string
+		want string
+	}{
+		{"/chains/main/blocks/head", "/chains/{chain}/blocks/{block}"},
+		{"/chains/main/blocks", "/chains/{chain}/blocks"},
+		{"/chains/test/blocks/BLfEWKVudXH15N8nwHZehyLNjRuNLoJavJDjSZ7nq8ggfzbZ18p/context/contracts/tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc/balance", "/chains/{chain}/blocks/{block}/context/contracts/{id}/balance"},
+		{"/chains/main/blocks/head/context/delegates/tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc/staking_balance", "/chains/{chain}/blocks/{block}/context/delegates/{pkh}/staking_balance"},
+		{"/chains/main/blocks/head/context/delegates", "/chains/{chain}/blocks/{block}/context/delegates"},
+		{"/chains/main/blocks/head/context/raw/json/contracts/index/tz1SUgyRB8T5jXgXAwS33pgRHAKrafyg87Yc/frozen_balance/205/", "/chains/{chain}/blocks/{block}/context/raw/json/contracts/index/{id}/frozen_balance/{cycle}/"},
+		{"/chains/main/blocks/head/context/raw/json/cycle/205", "/chains/{chain}/blocks/{block}/context/raw/json/cycle/{cycle}"},
+		{"/chains/main/invalid_blocks/BLfEWKVudXH15N8nwHZehyLNjRuNLoJavJDjSZ7nq8ggfzbZ18p", "/chains/{chain}/invalid_blocks/{block_hash}"},
+		{"/chains/main/blocks/head/operations/3/12", "/chains/{chain}/blocks/{block}/operations/{pass}/{index}"},
+		{"/chains/main/blocks/head/operations/3", "/chains/{chain}/blocks/{block}/operations/{pass}"},
+		{"/chains/main/blocks/head/helpers/forge/operations", "/chains/{chain}/blocks/{block}/helpers/forge/operations"},
+		{"/chains/main/blocks/head/operation_hashes", "/chains/{chain}/blocks/{block}/operation_hashes"},
+		{"/monitor/heads/main", "/monitor/heads/{chain}"},
+		{"/injection/operation", "/injection/operation"},
+		{"/network/version", "/network/version"},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.path, func(t *testing.T) {
+			assert.Equal(t, tt.want, pathTemplate(tt.path))
+		})
+	}
+}
@@ -38,6 +38,7 @@ const (
 	invalidblocks      responseKey = ".test-fixtures/invalid_blocks.json"
 	operationhashes    responseKey = ".test-fixtures/operation_hashes.json"
 	parseOperations    responseKey = ".test-fixtures/parse_operations.json"
+	pendingOperations  responseKey = ".test-fixtures/pending_operations.json"
 	preapplyOperations responseKey = ".test-fixtures/preapply_operations.json"
 	rpcerrors          responseKey = ".test-fixtures/rpc_errors.json"
 	version            responseKey = ".test-fixtures/version.json"
@@ -155,6 +156,11 @@ func getResponse(key responseKey) interface{} {
 		var out []Operations
 		json.Unmarshal(f, &out)
 		return out
+	case pendingOperations:
+		f := readResponse(key)
+		var out Mempool
+		json.Unmarshal(f, &out)
+		return out
 	case preapplyOperations:
 		f := readResponse(key)
 		var out []Operations
@@ -206,6 +212,7 @@ var (
 	regInjectionOperation      = regexp.MustCompile(`\/injection\/operation`)
 	regInvalidBlocks           = regexp.MustCompile(`\/chains\/main\/invalid_blocks`)
 	regOperationHashes         = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/operation_hashes`)
+	regPendingOperations       = regexp.MustCompile(`\/chains\/main\/mempool\/pending_operations`)
 	regPreapplyOperations      = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/helpers\/preapply\/operations`)
 	regStakingBalance          = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/delegates\/[A-z0-9]+\/staking_balance`)
 	regStorage                 = regexp.MustCompile(`\/chains\/main\/blocks\/[A-z0-9]+\/context\/contracts\/[A-z0-9]+\/storage`)
@@ -481,6 +488,17 @@ func invalidBlocksHandlerMock(resp []byte, next http.Handler) http.Handler {
 	})
 }
 
+func pendingOperationsHandlerMock(resp []byte, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regPendingOperations.MatchString(r.URL.String()) {
+			w.Write(resp)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
 func operationHashesHandlerMock(resp []byte, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		if regOperationHashes.MatchString(r.URL.String()) {
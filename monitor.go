@@ -0,0 +1,255 @@
+package goMXP
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+MonitorHeadsInput is the input for the goMXP.MonitorHeads function.
+
+Function:
+	func (t *GoMXP) MonitorHeads(ctx context.Context, input MonitorHeadsInput) (<-chan *Block, <-chan error) {}
+*/
+type MonitorHeadsInput struct {
+	// The chain to monitor. Defaults to the chain of the call (see SetChain and WithChain).
+	Chain string
+
+	// Only monitor heads whose next protocol is this protocol hash.
+	NextProtocol string
+
+	// Fetch the full block of every head instead of only its hash and header.
+	FullBlock bool
+
+	// How long to wait before reconnecting after the stream fails. Defaults to 1s.
+	ReconnectDelay time.Duration
+}
+
+/*
+MonitorHeads keeps a streaming connection open to the node and delivers every new head of the chain.
+Unless FullBlock is set, only the Hash and Header of the delivered blocks are filled. The stream is
+reopened after ReconnectDelay whenever it fails, and a head that was already delivered before a
+reconnect is not delivered again.
+
+Errors, including the ones that caused a reconnect, are sent on the error channel. It is buffered
+and errors are dropped when it is full, so it never stalls the stream. Both channels are closed once
+ctx is done.
+
+Path:
+	/monitor/heads/<chain_id> (GET)
+
+Link:
+	https://MXP.gitlab.io/api/rpc.html#get-monitor-heads-chain-id
+
+Parameters:
+
+	ctx:
+		Cancel ctx to close the stream.
+
+	input:
+		Modifies the MonitorHeads stream.
+*/
+func (t *GoMXP) MonitorHeads(ctx context.Context, input MonitorHeadsInput) (<-chan *Block, <-chan error) {
+	if input.Chain == "" {
+		input.Chain = t.chainOf(ctx)
+	}
+
+	var opts []rpcOptions
+	if input.NextProtocol != "" {
+		opts = append(opts, rpcOptions{
+			"next_protocol",
+			input.NextProtocol,
+		})
+	}
+
+	heads := make(chan *Block)
+	errs := make(chan error, 10)
+
+	go func() {
+		defer close(heads)
+		defer close(errs)
+
+		var last string
+		t.follow(ctx, streamer{
+			path:  fmt.Sprintf("/monitor/heads/%s", input.Chain),
+			opts:  opts,
+			delay: input.ReconnectDelay,
+			chunk: func(chunk json.RawMessage) error {
+				head, err := decodeHead(chunk)
+				if err != nil {
+					return errors.Wrap(err, "could not unmarshal head")
+				}
+
+				if head.Hash == last {
+					return nil
+				}
+
+				if input.FullBlock {
+					head, err = t.BlockContext(ctx, head.Hash)
+					if err != nil {
+						return err
+					}
+				}
+
+				select {
+				case heads <- head:
+					last = head.Hash
+					return nil
+				case <-ctx.Done():
+					return ctx.Err()
+				}
+			},
+		}, errs)
+	}()
+
+	return heads, errs
+}
+
+// decodeHead decodes a head sent by /monitor/heads, which is a block header with the block hash alongside.
+func decodeHead(chunk json.RawMessage) (*Block, error) {
+	var head struct {
+		Hash string `json:"hash"`
+		Header
+	}
+	err := json.Unmarshal(chunk, &head)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Block{Hash: head.Hash, Header: head.Header}, nil
+}
+
+// streamer describes a streaming RPC for follow.
+type streamer struct {
+	path  string
+	opts  []rpcOptions
+	delay time.Duration
+
+	// resume reopens the stream immediately, without reporting an error, when the node ends it
+	// normally after sending at least one value.
+	resume bool
+
+	// connect, if set, is called before every connection.
+	connect func()
+
+	// chunk is called with every JSON value the node sends.
+	chunk func(chunk json.RawMessage) error
+}
+
+// follow keeps the stream open until ctx is done, reopening it after s.delay whenever it fails.
+// Every failure is reported on errs without blocking.
+func (t *GoMXP) follow(ctx context.Context, s streamer, errs chan<- error) {
+	if s.delay <= 0 {
+		s.delay = time.Second
+	}
+
+	for {
+		if s.connect != nil {
+			s.connect()
+		}
+
+		var chunks int
+		err := t.stream(ctx, s.path, func(chunk json.RawMessage) error {
+			chunks++
+			return s.chunk(chunk)
+		}, s.opts...)
+		if ctx.Err() != nil {
+			return
+		}
+
+		if s.resume && err == errStreamClosed && chunks > 0 {
+			continue
+		}
+
+		select {
+		case errs <- errors.Wrapf(err, "stream '%s' failed, reconnecting in %s", s.path, s.delay):
+		default:
+		}
+
+		timer := time.NewTimer(s.delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+var errStreamClosed = errors.New("stream closed by node")
+
+// stream opens the chunked streaming RPC at path and calls fn with every JSON value the node sends
+// until the stream ends, ctx is done, or fn returns an error.
+func (t *GoMXP) stream(ctx context.Context, path string, fn func(chunk json.RawMessage) error, opts ...rpcOptions) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%s", t.host, path), nil)
+	if err != nil {
+		return errors.Wrap(err, "failed to construct request")
+	}
+
+	cfg := t.config()
+	constructQueryParams(req, opts...)
+	cfg.setHeaders(req)
+
+	resp, err := cfg.streamClient().Do(req)
+	if err != nil {
+		return &RequestError{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Err:    errors.Wrap(err, "failed to open stream"),
+		}
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		byts, _ := ioutil.ReadAll(resp.Body)
+		return handleRPCError(req, resp.StatusCode, byts)
+	}
+
+	dec := json.NewDecoder(resp.Body)
+	for {
+		var chunk json.RawMessage
+		err := dec.Decode(&chunk)
+		if err == io.EOF {
+			return errStreamClosed
+		} else if err != nil {
+			return errors.Wrap(err, "failed to read stream")
+		}
+
+		err = fn(chunk)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// streamRouter is implemented by clients, such as Pool, that send streams through a client of their own.
+type streamRouter interface {
+	streamClient() client
+}
+
+// streamClient returns the client without its overall request timeout, which would otherwise cut long-lived streams.
+func (c *config) streamClient() client {
+	if s, ok := c.client.(streamRouter); ok {
+		return s.streamClient()
+	}
+
+	return withoutTimeout(c.client)
+}
+
+// withoutTimeout returns a copy of an *http.Client without its overall request timeout, or c itself.
+func withoutTimeout(c client) client {
+	if httpClient, ok := c.(*http.Client); ok && httpClient.Timeout > 0 {
+		streaming := *httpClient
+		streaming.Timeout = 0
+		return &streaming
+	}
+
+	return c
+}
@@ -0,0 +1,110 @@
+package goMXP
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var regMonitorHeads = regexp.MustCompile(`\/monitor\/heads\/main`)
+
+func mockHead(hash string, level int) []byte {
+	return []byte(fmt.Sprintf(`{"hash":"%s","level":%d,"proto":5,"predecessor":"BLJmTCrauYh6wx6ej75yeY6tK9HbTu3xBc1KUU5Rxbw8sQutwn7","timestamp":"2020-02-25T12:04:25Z","validation_pass":4,"operations_hash":"LLoZr4zsAszKDFvST1xRCF7LJ8h4sGdUfVGFCLJFKznnz4gLYfcnT","fitness":["01","000000000002d001"],"context":"CoVHfjRNd5t84SzSpKsAq2LQn9LLMKmJZhJtAXVucK3cJFQnbBGt","protocol_data":"00"}`+"\n", hash, level))
+}
+
+// monitorHeadsHandlerMock streams the heads of each connection in turn. The last connection is held open until the client goes away.
+func monitorHeadsHandlerMock(connections [][][]byte, next http.Handler) http.Handler {
+	var count int32
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !regMonitorHeads.MatchString(r.URL.String()) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		i := int(atomic.AddInt32(&count, 1) - 1)
+		if i >= len(connections) {
+			i = len(connections) - 1
+		}
+
+		for _, head := range connections[i] {
+			w.Write(head)
+			w.(http.Flusher).Flush()
+		}
+
+		if i == len(connections)-1 {
+			<-r.Context().Done()
+		}
+	})
+}
+
+func Test_MonitorHeads(t *testing.T) {
+	t.Run("delivers heads across reconnects", func(t *testing.T) {
+		server := httptest.NewServer(gtGoldenHTTPMock(monitorHeadsHandlerMock([][][]byte{
+			{mockHead("BLa", 1), mockHead("BLb", 2)},
+			{mockHead("BLb", 2), mockHead("BLc", 3)},
+		}, blankHandler)))
+		defer server.Close()
+
+		gt, err := New(server.URL)
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		heads, errs := gt.MonitorHeads(ctx, MonitorHeadsInput{ReconnectDelay: time.Millisecond})
+
+		var levels []int
+		for i := 0; i < 3; i++ {
+			head := <-heads
+			assert.Equal(t, fmt.Sprintf("BL%c", 'a'+i), head.Hash)
+			assert.Equal(t, "BLJmTCrauYh6wx6ej75yeY6tK9HbTu3xBc1KUU5Rxbw8sQutwn7", head.Header.Predecessor)
+			levels = append(levels, head.Header.Level)
+		}
+		assert.Equal(t, []int{1, 2, 3}, levels)
+		checkErr(t, true, "stream closed by node", <-errs)
+
+		cancel()
+		_, ok := <-heads
+		assert.False(t, ok)
+	})
+
+	t.Run("fetches full blocks", func(t *testing.T) {
+		server := httptest.NewServer(gtGoldenHTTPMock(monitorHeadsHandlerMock([][][]byte{
+			{mockHead("BLfEWKVudXH15N8nwHZehyLNjRuNLoJavJDjSZ7nq8ggfzbZ18p", 839681)},
+		}, newBlockMock().handler(readResponse(block), blankHandler))))
+		defer server.Close()
+
+		gt, err := New(server.URL)
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		heads, _ := gt.MonitorHeads(ctx, MonitorHeadsInput{FullBlock: true})
+		assert.Equal(t, getResponse(block).(*Block), <-heads)
+	})
+
+	t.Run("reports rpc errors", func(t *testing.T) {
+		server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(readResponse(rpcerrors))
+		})))
+		defer server.Close()
+
+		gt, err := New(server.URL)
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		heads, errs := gt.MonitorHeads(ctx, MonitorHeadsInput{ReconnectDelay: time.Millisecond})
+		checkErr(t, true, "rpc error (somekind): someerror", <-errs)
+
+		cancel()
+		_, ok := <-heads
+		assert.False(t, ok)
+	})
+}
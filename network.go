@@ -1,6 +1,7 @@
 package goMXP
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"time"
@@ -145,7 +146,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-network-version
 */
 func (t *GoMXP) Version() (Version, error) {
-	resp, err := t.get("/network/version")
+	return t.VersionContext(context.Background())
+}
+
+// VersionContext is Version bound to ctx for cancellation and deadlines.
+func (t *GoMXP) VersionContext(ctx context.Context) (Version, error) {
+	resp, err := t.get(ctx, "/network/version")
 	if err != nil {
 		return Version{}, errors.Wrap(err, "could not get network version")
 	}
@@ -169,7 +175,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-block-id-context-constants
 */
 func (t *GoMXP) Constants(blockhash string) (Constants, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/constants", blockhash))
+	return t.ConstantsContext(context.Background(), blockhash)
+}
+
+// ConstantsContext is Constants bound to ctx for cancellation and deadlines.
+func (t *GoMXP) ConstantsContext(ctx context.Context, blockhash string) (Constants, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/context/constants", t.chainPath(ctx), blockhash))
 	if err != nil {
 		return Constants{}, errors.Wrapf(err, "could not get network constants")
 	}
@@ -193,7 +204,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-network-connections
 */
 func (t *GoMXP) Connections() (Connections, error) {
-	resp, err := t.get("/network/connections")
+	return t.ConnectionsContext(context.Background())
+}
+
+// ConnectionsContext is Connections bound to ctx for cancellation and deadlines.
+func (t *GoMXP) ConnectionsContext(ctx context.Context) (Connections, error) {
+	resp, err := t.get(ctx, "/network/connections")
 	if err != nil {
 		return Connections{}, errors.Wrapf(err, "could not get network connections")
 	}
@@ -219,7 +235,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-monitor-bootstrapped
 */
 func (t *GoMXP) Bootstrap() (Bootstrap, error) {
-	resp, err := t.get("/monitor/bootstrapped")
+	return t.BootstrapContext(context.Background())
+}
+
+// BootstrapContext is Bootstrap bound to ctx for cancellation and deadlines.
+func (t *GoMXP) BootstrapContext(ctx context.Context) (Bootstrap, error) {
+	resp, err := t.get(ctx, "/monitor/bootstrapped")
 	if err != nil {
 		return Bootstrap{}, errors.Wrap(err, "could not get bootstrap")
 	}
@@ -243,7 +264,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-monitor-commit-hash
 */
 func (t *GoMXP) Commit() (string, error) {
-	resp, err := t.get("/monitor/commit_hash")
+	return t.CommitContext(context.Background())
+}
+
+// CommitContext is Commit bound to ctx for cancellation and deadlines.
+func (t *GoMXP) CommitContext(ctx context.Context) (string, error) {
+	resp, err := t.get(ctx, "/monitor/commit_hash")
 	if err != nil {
 		return "", errors.Wrap(err, "could not get commit hash")
 	}
@@ -267,40 +293,50 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-block-id-context-raw-bytes
 */
 func (t *GoMXP) Cycle(cycle int) (Cycle, error) {
-	head, err := t.Head()
+	return t.CycleContext(context.Background(), cycle)
+}
+
+// CycleContext is Cycle bound to ctx for cancellation and deadlines.
+func (t *GoMXP) CycleContext(ctx context.Context, cycle int) (Cycle, error) {
+	constants, err := t.constants(ctx)
 	if err != nil {
 		return Cycle{}, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 	}
 
-	if cycle > head.Metadata.Level.Cycle+t.networkConstants.PreservedCycles-1 {
+	head, err := t.HeadContext(ctx)
+	if err != nil {
+		return Cycle{}, errors.Wrapf(err, "could not get cycle '%d'", cycle)
+	}
+
+	if cycle > head.Metadata.Level.Cycle+constants.PreservedCycles-1 {
 		return Cycle{}, errors.Errorf("could not get cycle '%d': request is in the future", cycle)
 	}
 
 	var c Cycle
 	if cycle < head.Metadata.Level.Cycle {
-		block, err := t.Block(cycle*t.networkConstants.BlocksPerCycle + 1)
+		block, err := t.BlockContext(ctx, cycle*constants.BlocksPerCycle + 1)
 		if err != nil {
 			return Cycle{}, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 		}
-		c, err = t.getCycleAtHash(block.Hash, cycle)
+		c, err = t.getCycleAtHash(ctx, block.Hash, cycle)
 		if err != nil {
 			return Cycle{}, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 		}
 
 	} else {
 		var err error
-		c, err = t.getCycleAtHash(head.Hash, cycle)
+		c, err = t.getCycleAtHash(ctx, head.Hash, cycle)
 		if err != nil {
 			return Cycle{}, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 		}
 	}
 
-	level := ((cycle - t.networkConstants.PreservedCycles - 2) * t.networkConstants.BlocksPerCycle) + (c.RollSnapshot+1)*t.networkConstants.BlocksPerRollSnapshot
+	level := ((cycle - constants.PreservedCycles - 2) * constants.BlocksPerCycle) + (c.RollSnapshot+1)*constants.BlocksPerRollSnapshot
 	if level < 1 {
 		level = 1
 	}
 
-	block, err := t.Block(level)
+	block, err := t.BlockContext(ctx, level)
 	if err != nil {
 		return c, errors.Wrapf(err, "could not get cycle '%d'", cycle)
 	}
@@ -309,8 +345,8 @@ func (t *GoMXP) Cycle(cycle int) (Cycle, error) {
 	return c, nil
 }
 
-func (t *GoMXP) getCycleAtHash(blockhash string, cycle int) (Cycle, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/raw/json/cycle/%d", blockhash, cycle))
+func (t *GoMXP) getCycleAtHash(ctx context.Context, blockhash string, cycle int) (Cycle, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/context/raw/json/cycle/%d", t.chainPath(ctx), blockhash, cycle))
 	if err != nil {
 		return Cycle{}, errors.Wrapf(err, "could not get cycle at hash '%s'", blockhash)
 	}
@@ -334,7 +370,12 @@ Link:
 	https://MXP.gitlab.io/api/rpc.html#get-monitor-active-chains
 */
 func (t *GoMXP) ActiveChains() (ActiveChains, error) {
-	resp, err := t.get("/monitor/active_chains")
+	return t.ActiveChainsContext(context.Background())
+}
+
+// ActiveChainsContext is ActiveChains bound to ctx for cancellation and deadlines.
+func (t *GoMXP) ActiveChainsContext(ctx context.Context) (ActiveChains, error) {
+	resp, err := t.get(ctx, "/monitor/active_chains")
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to get active chains")
 	}
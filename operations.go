@@ -1,6 +1,7 @@
 package goMXP
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -38,7 +39,7 @@ type InjectionOperationInput struct {
 	// If ?async is true, the function returns immediately.
 	Async bool
 
-	// Specify the ChainID.
+	// Specify the ChainID. Defaults to the chain of the call (see SetChain and WithChain).
 	ChainID *string
 }
 
@@ -58,7 +59,7 @@ type InjectionBlockInput struct {
 	// If ?force is true, it will be injected even on non strictly increasing fitness.
 	Force bool
 
-	// Specify the ChainID.
+	// Specify the ChainID. Defaults to the chain of the call (see SetChain and WithChain).
 	ChainID *string
 }
 
@@ -252,6 +253,11 @@ Parameters:
 		PreapplyOperationsInput contains the blockhash, protocol, signature, and operation contents needed to fufill this RPC.
 */
 func (t *GoMXP) PreapplyOperations(input PreapplyOperationsInput) ([]Operations, error) {
+	return t.PreapplyOperationsContext(context.Background(), input)
+}
+
+// PreapplyOperationsContext is PreapplyOperations bound to ctx for cancellation and deadlines.
+func (t *GoMXP) PreapplyOperationsContext(ctx context.Context, input PreapplyOperationsInput) ([]Operations, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return nil, errors.Wrap(err, "invalid input")
@@ -271,7 +277,7 @@ func (t *GoMXP) PreapplyOperations(input PreapplyOperationsInput) ([]Operations,
 		return nil, errors.Wrap(err, "failed to preapply operation")
 	}
 
-	resp, err := t.post(fmt.Sprintf("/chains/main/blocks/%s/helpers/preapply/operations", input.Blockhash), op)
+	resp, err := t.post(ctx, fmt.Sprintf("%s/blocks/%s/helpers/preapply/operations", t.chainPath(ctx), input.Blockhash), op)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to preapply operation")
 	}
@@ -306,6 +312,11 @@ Parameters:
 		Modifies the InjectionOperation RPC query by passing optional URL parameters. Operation is required.
 */
 func (t *GoMXP) InjectionOperation(input InjectionOperationInput) (string, error) {
+	return t.InjectionOperationContext(context.Background(), input)
+}
+
+// InjectionOperationContext is InjectionOperation bound to ctx for cancellation and deadlines.
+func (t *GoMXP) InjectionOperationContext(ctx context.Context, input InjectionOperationInput) (string, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return "", errors.Wrap(err, "invalid input")
@@ -315,7 +326,12 @@ func (t *GoMXP) InjectionOperation(input InjectionOperationInput) (string, error
 	if err != nil {
 		return "", errors.Wrap(err, "failed to inject operation")
 	}
-	resp, err := t.post("/injection/operation", v, input.contructRPCOptions()...)
+
+	if chain := t.chainOf(ctx); input.ChainID == nil && chain != "main" {
+		input.ChainID = &chain
+	}
+
+	resp, err := t.post(ctx, "/injection/operation", v, input.contructRPCOptions()...)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to inject operation")
 	}
@@ -374,6 +390,11 @@ Parameters:
 		The contents of the of the operation.
 */
 func (t *GoMXP) ForgeOperationWithRPC(input ForgeOperationWithRPCInput) (string, error) {
+	return t.ForgeOperationWithRPCContext(context.Background(), input)
+}
+
+// ForgeOperationWithRPCContext is ForgeOperationWithRPC bound to ctx for cancellation and deadlines.
+func (t *GoMXP) ForgeOperationWithRPCContext(ctx context.Context, input ForgeOperationWithRPCInput) (string, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return "", errors.Wrap(err, "invalid input")
@@ -389,7 +410,7 @@ func (t *GoMXP) ForgeOperationWithRPC(input ForgeOperationWithRPCInput) (string,
 		return "", errors.Wrap(err, "failed to forge operation")
 	}
 
-	resp, err := t.post(fmt.Sprintf("/chains/main/blocks/%s/helpers/forge/operations", input.Blockhash), v)
+	resp, err := t.post(ctx, fmt.Sprintf("%s/blocks/%s/helpers/forge/operations", t.chainPath(ctx), input.Blockhash), v)
 	if err != nil {
 		return "", errors.Wrap(err, "failed to forge operation")
 	}
@@ -411,11 +432,12 @@ func (t *GoMXP) ForgeOperationWithRPC(input ForgeOperationWithRPCInput) (string,
 		if err != nil {
 			return operation, errors.Wrap(err, "failed to forge operation: unable to verify rpc returned a valid contents with alternative node")
 		}
+		gt.SetChain(t.chainOf(ctx))
 	} else {
 		gt = t
 	}
 
-	operations, err := gt.UnforgeOperationWithRPC(input.Blockhash, UnforgeOperationWithRPCInput{
+	operations, err := gt.UnforgeOperationWithRPCContext(ctx, input.Blockhash, UnforgeOperationWithRPCInput{
 		Operations: []UnforgeOperationWithRPCOperation{
 			{
 				Data:   fmt.Sprintf("%s00000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000000", opstr),
@@ -470,6 +492,11 @@ Parameters:
 		Contains the operations and the option to verify the operations signatures.
 */
 func (t *GoMXP) UnforgeOperationWithRPC(blockhash string, input UnforgeOperationWithRPCInput) ([]Operations, error) {
+	return t.UnforgeOperationWithRPCContext(context.Background(), blockhash, input)
+}
+
+// UnforgeOperationWithRPCContext is UnforgeOperationWithRPC bound to ctx for cancellation and deadlines.
+func (t *GoMXP) UnforgeOperationWithRPCContext(ctx context.Context, blockhash string, input UnforgeOperationWithRPCInput) ([]Operations, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return []Operations{}, errors.Wrap(err, "invalid input")
@@ -480,7 +507,7 @@ func (t *GoMXP) UnforgeOperationWithRPC(blockhash string, input UnforgeOperation
 		return []Operations{}, errors.Wrap(err, "failed to unforge forge operations with RPC")
 	}
 
-	resp, err := t.post(fmt.Sprintf("/chains/main/blocks/%s/helpers/parse/operations", blockhash), v)
+	resp, err := t.post(ctx, fmt.Sprintf("%s/blocks/%s/helpers/parse/operations", t.chainPath(ctx), blockhash), v)
 	if err != nil {
 		return []Operations{}, errors.Wrap(err, "failed to unforge forge operations with RPC")
 	}
@@ -516,6 +543,11 @@ Parameters:
 		Modifies the InjectionBlock RPC query by passing optional URL parameters. Block is required.
 */
 func (t *GoMXP) InjectionBlock(input InjectionBlockInput) ([]byte, error) {
+	return t.InjectionBlockContext(context.Background(), input)
+}
+
+// InjectionBlockContext is InjectionBlock bound to ctx for cancellation and deadlines.
+func (t *GoMXP) InjectionBlockContext(ctx context.Context, input InjectionBlockInput) ([]byte, error) {
 	err := validator.New().Struct(input)
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "invalid input")
@@ -525,7 +557,12 @@ func (t *GoMXP) InjectionBlock(input InjectionBlockInput) ([]byte, error) {
 	if err != nil {
 		return []byte{}, errors.Wrap(err, "failed to inject block")
 	}
-	resp, err := t.post("/injection/block", v, input.contructRPCOptions()...)
+
+	if chain := t.chainOf(ctx); input.ChainID == nil && chain != "main" {
+		input.ChainID = &chain
+	}
+
+	resp, err := t.post(ctx, "/injection/block", v, input.contructRPCOptions()...)
 	if err != nil {
 		return resp, errors.Wrap(err, "failed to inject block")
 	}
@@ -575,7 +612,12 @@ Parameters:
 		The pkh (address) of the contract for the query.
 */
 func (t *GoMXP) Counter(blockhash, pkh string) (int, error) {
-	resp, err := t.get(fmt.Sprintf("/chains/main/blocks/%s/context/contracts/%s/counter", blockhash, pkh))
+	return t.CounterContext(context.Background(), blockhash, pkh)
+}
+
+// CounterContext is Counter bound to ctx for cancellation and deadlines.
+func (t *GoMXP) CounterContext(ctx context.Context, blockhash, pkh string) (int, error) {
+	resp, err := t.get(ctx, fmt.Sprintf("%s/blocks/%s/context/contracts/%s/counter", t.chainPath(ctx), blockhash, pkh))
 	if err != nil {
 		return 0, errors.Wrapf(err, "failed to get counter")
 	}
@@ -594,7 +636,7 @@ func (t *GoMXP) Counter(blockhash, pkh string) (int, error) {
 
 /*
 ForgeOperation forges an operation locally. GoMXP does not use the RPC or a trusted source to forge operations.
-Current supported operations include transfer, reveal, delegation, and origination.
+Current supported operations include transfer, reveal, delegation, origination, and endorsement.
 
 Parameters:
 
@@ -639,12 +681,8 @@ func ForgeOperation(branch string, contents ...Contents) (string, error) {
 				return "", errors.Wrap(err, "failed to forge operation")
 			}
 			sb.WriteString(forge)
-		// case ENDORSEMENTOP:
-		// 	forge, err := forgeEndorsementOperation(c)
-		// 	if err != nil {
-		// 		return "", errors.Wrap(err, "failed to forge operation")
-		// 	}
-		// 	sb.WriteString(forge)
+		case ENDORSEMENTOP:
+			sb.WriteString(forgeEndorsementOperation(c))
 		default:
 			return "", fmt.Errorf("failed to forge operation: unsupported kind %s", c.Kind)
 		}
@@ -975,19 +1013,9 @@ func forgeDelegationOperation(contents Contents) (string, error) {
 	return sb.String(), nil
 }
 
-// func forgeEndorsementOperation(contents Contents) (string, error) {
-// 	err := validateEndorsement(contents)
-// 	if err != nil {
-// 		return "", errors.Wrap(err, "failed to forge endorsement operation")
-// 	}
-// 	var sb strings.Builder
-// 	sb.WriteString("30")
-
-// 	level := NewInt(contents.Level)
-// 	sb.WriteString(bigNumberToZarith(*level))
-
-// 	return sb.String(), nil
-// }
+func forgeEndorsementOperation(contents Contents) string {
+	return fmt.Sprintf("00%08x", uint32(contents.Level))
+}
 
 func forgeCommonFields(contents Contents) (string, error) {
 	source, err := removeHexPrefix(contents.Source, tz1prefix)
@@ -0,0 +1,224 @@
+package goMXP
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"golang.org/x/crypto/blake2b"
+)
+
+/*
+OperationHashError is returned by Block.VerifyOperations when the hash of an operation in a block does
+not match the hash of its forged, signed bytes.
+*/
+type OperationHashError struct {
+	Pass     int
+	Index    int
+	Hash     string
+	Computed string
+}
+
+func (o *OperationHashError) Error() string {
+	return fmt.Sprintf("hash of operation %d in validation pass %d does not match its contents: node returned '%s', contents hash to '%s'", o.Index, o.Pass, o.Hash, o.Computed)
+}
+
+/*
+UnsupportedOperationError is returned when an operation has contents GoMXP does not forge locally, so
+its hash can not be computed: kinds other than transactions, reveals, originations, delegations and
+endorsements, transactions with parameters, originations with a script, and sources, destinations,
+delegates or public keys other than tz1 and edpk ones.
+*/
+type UnsupportedOperationError struct {
+	Pass   int
+	Index  int
+	Hash   string
+	Reason string
+}
+
+func (u *UnsupportedOperationError) Error() string {
+	return fmt.Sprintf("operation '%s' can not be forged locally: %s", u.Hash, u.Reason)
+}
+
+/*
+VerifyOperationsError is returned by Block.VerifyOperations when any operation of the block could not
+be verified. Mismatches holds every operation whose hash does not match its contents, Unsupported every
+operation that can not be forged locally, and Errors every other operation that failed to forge. Only
+Mismatches mean the block differs from what the node hashed; errors.As finds an *OperationHashError in them.
+*/
+type VerifyOperationsError struct {
+	Mismatches  []*OperationHashError
+	Unsupported []*UnsupportedOperationError
+	Errors      []error
+}
+
+func (v *VerifyOperationsError) Error() string {
+	var errs []string
+	for _, err := range v.Unwrap() {
+		errs = append(errs, err.Error())
+	}
+
+	return fmt.Sprintf("failed to verify %d operations: %s", len(errs), strings.Join(errs, "; "))
+}
+
+// Unwrap returns the mismatches followed by the unsupported operations and the other errors.
+func (v *VerifyOperationsError) Unwrap() []error {
+	var errs []error
+	for _, mismatch := range v.Mismatches {
+		errs = append(errs, mismatch)
+	}
+	for _, unsupported := range v.Unsupported {
+		errs = append(errs, unsupported)
+	}
+
+	return append(errs, v.Errors...)
+}
+
+// As sets target to the first error of Unwrap it matches, for versions of errors.As that only follow a single wrapped error.
+func (v *VerifyOperationsError) As(target interface{}) bool {
+	for _, err := range v.Unwrap() {
+		if errors.As(err, target) {
+			return true
+		}
+	}
+
+	return false
+}
+
+/*
+OperationHash computes the hash of an operation locally from its forged, signed bytes: the blake2b
+digest of the operation, base58 encoded with the o prefix. It is the hash the node returns when the
+operation is injected.
+
+Parameters:
+
+	signedOperation:
+		The hex encoded forged operation with its signature appended.
+*/
+func OperationHash(signedOperation string) (string, error) {
+	byts, err := hex.DecodeString(signedOperation)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to hash operation: invalid hex")
+	}
+
+	if len(byts) <= 32+64 {
+		return "", errors.Errorf("failed to hash operation: operation too short (%d bytes) to be forged and signed", len(byts))
+	}
+
+	hash := blake2b.Sum256(byts)
+	return b58cencode(hash[:], operationprefix), nil
+}
+
+/*
+ComputeHash computes the hash of the operation locally by forging its branch and contents and appending its
+signature. Operations that can not be forged locally return an *UnsupportedOperationError.
+*/
+func (o *Operations) ComputeHash() (string, error) {
+	contents, err := o.forgeableContents()
+	if err != nil {
+		return "", err
+	}
+
+	forged, err := ForgeOperation(o.Branch, contents...)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to compute operation hash")
+	}
+
+	signature, err := decodePrefixed(o.Signature, 64, sigprefix, edsigprefix, spsigprefix, p2sigprefix)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to compute operation hash: invalid signature")
+	}
+
+	return OperationHash(forged + hex.EncodeToString(signature))
+}
+
+// forgeableContents returns the contents of the operation as ForgeOperation forges them, or an
+// *UnsupportedOperationError if they can not be forged faithfully.
+func (o *Operations) forgeableContents() ([]Contents, error) {
+	unsupported := func(format string, args ...interface{}) error {
+		return &UnsupportedOperationError{Hash: o.Hash, Reason: fmt.Sprintf(format, args...)}
+	}
+
+	contents := make([]Contents, len(o.Contents))
+	for i, c := range o.Contents {
+		switch c.Kind {
+		case ENDORSEMENTOP:
+			contents[i] = c
+			continue
+		case TRANSACTIONOP, REVEALOP, ORIGINATIONOP, DELEGATIONOP:
+		default:
+			return nil, unsupported("kind %s is not supported", c.Kind)
+		}
+
+		if !strings.HasPrefix(c.Source, "tz1") {
+			return nil, unsupported("source '%s' is not a tz1 address", c.Source)
+		}
+
+		switch c.Kind {
+		case TRANSACTIONOP:
+			if c.Parameters != nil {
+				return nil, unsupported("transaction parameters are not supported")
+			}
+			if !strings.HasPrefix(c.Destination, "tz1") && !strings.HasPrefix(c.Destination, "KT1") {
+				return nil, unsupported("destination '%s' is not a tz1 or KT1 address", c.Destination)
+			}
+		case REVEALOP:
+			// blocks return the revealed key as public_key, which is forged from Phk
+			if c.Phk == "" {
+				c.Phk = c.PublicKey
+			}
+			if !strings.HasPrefix(c.Phk, "edpk") {
+				return nil, unsupported("public key '%s' is not an edpk key", c.Phk)
+			}
+		case ORIGINATIONOP:
+			if c.Script != nil {
+				return nil, unsupported("origination scripts are not supported")
+			}
+		}
+
+		if c.Delegate != "" && !strings.HasPrefix(c.Delegate, "tz1") {
+			return nil, unsupported("delegate '%s' is not a tz1 address", c.Delegate)
+		}
+
+		contents[i] = c
+	}
+
+	return contents, nil
+}
+
+/*
+VerifyOperations re-forges and re-hashes every operation in the block and checks the result against
+the hash the node returned for it. All failures are returned together as a *VerifyOperationsError.
+
+Operations GoMXP can not forge locally (see UnsupportedOperationError), such as contract calls and
+originations, are reported in its Unsupported field rather than as mismatches, as they could not be
+checked. Callers that only want to detect tampering should look at its Mismatches.
+*/
+func (b *Block) VerifyOperations() error {
+	verifyErr := &VerifyOperationsError{}
+	for pass, operations := range b.Operations {
+		for index, operation := range operations {
+			hash, err := operation.ComputeHash()
+			if unsupported, ok := err.(*UnsupportedOperationError); ok {
+				unsupported.Pass, unsupported.Index = pass, index
+				verifyErr.Unsupported = append(verifyErr.Unsupported, unsupported)
+				continue
+			}
+			if err != nil {
+				verifyErr.Errors = append(verifyErr.Errors, errors.Wrapf(err, "failed to verify operation '%s'", operation.Hash))
+				continue
+			}
+
+			if hash != operation.Hash {
+				verifyErr.Mismatches = append(verifyErr.Mismatches, &OperationHashError{Pass: pass, Index: index, Hash: operation.Hash, Computed: hash})
+			}
+		}
+	}
+
+	if len(verifyErr.Mismatches) == 0 && len(verifyErr.Unsupported) == 0 && len(verifyErr.Errors) == 0 {
+		return nil
+	}
+
+	return verifyErr
+}
@@ -0,0 +1,174 @@
+package goMXP
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_OperationHash(t *testing.T) {
+	goldenBlock := getResponse(block).(*Block)
+	transaction := goldenBlock.Operations[3][1]
+	forged, err := ForgeOperation(transaction.Branch, transaction.Contents...)
+	assert.Nil(t, err)
+	signature, err := decodePrefixed(transaction.Signature, 64, sigprefix)
+	assert.Nil(t, err)
+
+	type want struct {
+		err         bool
+		errContains string
+		hash        string
+	}
+
+	cases := []struct {
+		name  string
+		input string
+		want  want
+	}{
+		{
+			"is successful",
+			forged + hex.EncodeToString(signature),
+			want{false, "", "onpjUwLcwfCCQyy2ndNvrTF2W64i782EzqFRHUqWkWQJik26eDq"},
+		},
+		{
+			"handles invalid hex",
+			"zz",
+			want{true, "failed to hash operation: invalid hex", ""},
+		},
+		{
+			"handles unsigned operation",
+			forged[:128],
+			want{true, "failed to hash operation: operation too short (64 bytes)", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			hash, err := OperationHash(tt.input)
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			assert.Equal(t, tt.want.hash, hash)
+		})
+	}
+}
+
+func Test_Operations_ComputeHash(t *testing.T) {
+	goldenBlock := getResponse(block).(*Block)
+
+	endorsement := goldenBlock.Operations[0][0]
+	hash, err := endorsement.ComputeHash()
+	assert.Nil(t, err)
+	assert.Equal(t, "oo7SNAowCeQKbXtLWkPxRyYUY3DptoXjck4axXRdqbp4V6tru4S", hash)
+
+	endorsement.Signature = "sig"
+	_, err = endorsement.ComputeHash()
+	checkErr(t, true, "failed to compute operation hash: invalid signature", err)
+
+	endorsement.Contents = []Contents{{Kind: "double_baking_evidence"}}
+	_, err = endorsement.ComputeHash()
+	checkErr(t, true, "can not be forged locally: kind double_baking_evidence is not supported", err)
+	var unsupported *UnsupportedOperationError
+	assert.True(t, errors.As(err, &unsupported))
+}
+
+func Test_Block_VerifyOperations(t *testing.T) {
+	goldenBlock := getResponse(block).(*Block)
+	assert.Nil(t, goldenBlock.VerifyOperations())
+
+	tampered := getResponse(block).(*Block)
+	tampered.Operations[3][1].Contents[0].Amount = NewInt(1)
+	err := tampered.VerifyOperations()
+	var hashErr *OperationHashError
+	if assert.True(t, errors.As(err, &hashErr)) {
+		assert.Equal(t, 3, hashErr.Pass)
+		assert.Equal(t, 1, hashErr.Index)
+		assert.Equal(t, "onpjUwLcwfCCQyy2ndNvrTF2W64i782EzqFRHUqWkWQJik26eDq", hashErr.Hash)
+		assert.NotEqual(t, hashErr.Hash, hashErr.Computed)
+	}
+	checkErr(t, true, "hash of operation 1 in validation pass 3 does not match its contents", err)
+
+	tampered.Operations[0][1].Contents[0].Level++
+	err = tampered.VerifyOperations()
+	checkErr(t, true, "failed to verify 2 operations", err)
+	checkErr(t, true, "hash of operation 1 in validation pass 0 does not match its contents", err)
+	checkErr(t, true, "hash of operation 1 in validation pass 3 does not match its contents", err)
+
+	var verifyErr *VerifyOperationsError
+	if assert.True(t, errors.As(err, &verifyErr)) && assert.Len(t, verifyErr.Mismatches, 2) {
+		assert.Equal(t, 0, verifyErr.Mismatches[0].Pass)
+		assert.Equal(t, 3, verifyErr.Mismatches[1].Pass)
+		assert.Empty(t, verifyErr.Errors)
+	}
+	if assert.True(t, errors.As(err, &hashErr)) {
+		assert.Equal(t, 0, hashErr.Pass)
+	}
+	assert.True(t, verifyErr.As(&hashErr))
+
+	tampered.Operations[3][2].Signature = "invalid"
+	err = tampered.VerifyOperations()
+	if assert.True(t, errors.As(err, &verifyErr)) {
+		assert.Len(t, verifyErr.Mismatches, 2)
+		assert.Len(t, verifyErr.Errors, 1)
+	}
+	checkErr(t, true, "failed to compute operation hash: invalid signature", err)
+}
+
+func Test_Block_VerifyOperations_unsupported(t *testing.T) {
+	var operations []Operations
+	err := json.Unmarshal([]byte(`[
+		{
+			"hash": "ooCall",
+			"branch": "BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1",
+			"contents": [{
+				"kind": "transaction", "source": "tz1Q8QkSBS63ZQnH3fBTiAMPes9R666Rn6Sc", "fee": "1792", "counter": "1",
+				"gas_limit": "10600", "storage_limit": "0", "amount": "0", "destination": "KT1LfoE9EbpdsfUzowRckGUfikGcd5PyVKg",
+				"parameters": {"entrypoint": "transfer", "value": {"prim": "Pair", "args": [{"string": "tz1a"}, {"int": "1"}]}}
+			}],
+			"signature": "sigTBpkXw6tC72L2nJ2r2Jm5iB6uidTWn9U9vpxAGkNNWsNLN5DsfdfYzxJ7iPrBFNv1CUbZ7s4mXE8W1sGx1p25bf7rQ3v1"
+		},
+		{
+			"hash": "ooOrigination",
+			"branch": "BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1",
+			"contents": [{
+				"kind": "origination", "source": "tz1Q8QkSBS63ZQnH3fBTiAMPes9R666Rn6Sc", "fee": "1792", "counter": "2",
+				"gas_limit": "10600", "storage_limit": "257", "balance": "0",
+				"script": {"code": [{"prim": "parameter", "args": [{"prim": "unit"}]}], "storage": {"prim": "Unit"}}
+			}],
+			"signature": "sigTBpkXw6tC72L2nJ2r2Jm5iB6uidTWn9U9vpxAGkNNWsNLN5DsfdfYzxJ7iPrBFNv1CUbZ7s4mXE8W1sGx1p25bf7rQ3v1"
+		},
+		{
+			"hash": "ooTz2",
+			"branch": "BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1",
+			"contents": [{
+				"kind": "transaction", "source": "tz2BFTyPeYRzxd5aiBchbXN3WCZhx7BqbMBq", "fee": "1792", "counter": "3",
+				"gas_limit": "10600", "storage_limit": "0", "amount": "1", "destination": "tz1Q8QkSBS63ZQnH3fBTiAMPes9R666Rn6Sc"
+			}],
+			"signature": "sigTBpkXw6tC72L2nJ2r2Jm5iB6uidTWn9U9vpxAGkNNWsNLN5DsfdfYzxJ7iPrBFNv1CUbZ7s4mXE8W1sGx1p25bf7rQ3v1"
+		}
+	]`), &operations)
+	assert.Nil(t, err)
+	assert.Equal(t, "transfer", operations[0].Contents[0].Parameters.Entrypoint)
+	assert.NotNil(t, operations[1].Contents[0].Script)
+
+	b := getResponse(block).(*Block)
+	b.Operations[3] = append(b.Operations[3], operations...)
+	index := len(b.Operations[3]) - 3
+
+	err = b.VerifyOperations()
+	var verifyErr *VerifyOperationsError
+	if assert.True(t, errors.As(err, &verifyErr)) {
+		assert.Empty(t, verifyErr.Mismatches)
+		assert.Empty(t, verifyErr.Errors)
+		assert.Equal(t, []*UnsupportedOperationError{
+			{Pass: 3, Index: index, Hash: "ooCall", Reason: "transaction parameters are not supported"},
+			{Pass: 3, Index: index + 1, Hash: "ooOrigination", Reason: "origination scripts are not supported"},
+			{Pass: 3, Index: index + 2, Hash: "ooTz2", Reason: "source 'tz2BFTyPeYRzxd5aiBchbXN3WCZhx7BqbMBq' is not a tz1 address"},
+		}, verifyErr.Unsupported)
+	}
+
+	var hashErr *OperationHashError
+	assert.False(t, errors.As(err, &hashErr))
+	checkErr(t, true, "operation 'ooCall' can not be forged locally", err)
+}
@@ -0,0 +1,387 @@
+package goMXP
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+/*
+PoolInput is the input for the goMXP.NewPool function.
+
+Function:
+	func NewPool(input PoolInput) (*Pool, error) {}
+*/
+type PoolInput struct {
+	// The MXP nodes to pool.
+	// Required.
+	Hosts []string `validate:"required,min=1"`
+
+	// How often nodes are health checked. Defaults to 10s.
+	HealthCheckInterval time.Duration
+
+	// How many levels a node may trail the highest known head before it is considered unhealthy. Defaults to 2.
+	MaxLevelLag int
+}
+
+/*
+PoolNodeStatus is the last known health of a node in a Pool.
+*/
+type PoolNodeStatus struct {
+	Host    string
+	Healthy bool
+	Level   int
+	Err     error
+}
+
+/*
+Pool is a GoMXP backed by several MXP nodes. Nodes are health checked in the background
+(bootstrapped status and head level lag), every request is sent to a healthy node, and
+idempotent requests fail over to the next node when one stops responding. Pool satisfies IFace.
+*/
+type Pool struct {
+	*GoMXP
+	client   atomic.Value // client
+	nodes    []*poolNode
+	next     uint32
+	interval time.Duration
+	maxLag   int
+	cancel   context.CancelFunc
+	done     chan struct{}
+}
+
+type poolNode struct {
+	host   string
+	gt     *GoMXP
+	mu     sync.RWMutex
+	status PoolNodeStatus
+}
+
+/*
+NewPool returns a Pool over the given hosts. Nodes are health checked once before NewPool returns, and
+then every HealthCheckInterval until Close is called.
+
+Parameters:
+
+	input:
+		The hosts of the pool and its health check settings.
+*/
+func NewPool(input PoolInput) (*Pool, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	p := &Pool{
+		interval: input.HealthCheckInterval,
+		maxLag:   input.MaxLevelLag,
+		done:     make(chan struct{}),
+	}
+	p.SetClient(newHTTPClient(10*time.Second, nil))
+
+	if p.interval <= 0 {
+		p.interval = 10 * time.Second
+	}
+	if p.maxLag <= 0 {
+		p.maxLag = 2
+	}
+
+	for _, host := range input.Hosts {
+		host = cleanseHost(host)
+		p.nodes = append(p.nodes, &poolNode{
+			host:   host,
+			gt:     p.newGoMXP(host),
+			status: PoolNodeStatus{Host: host},
+		})
+	}
+
+	p.GoMXP = p.newGoMXP(p.nodes[0].host)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.checkHealth(ctx)
+
+	err = p.GoMXP.initNetworkConstants(ctx)
+	if err != nil {
+		cancel()
+		return nil, errors.Wrap(err, "failed to initialize pool")
+	}
+
+	go p.monitor(ctx)
+
+	return p, nil
+}
+
+/*
+Close stops the background health checks of the pool.
+*/
+func (p *Pool) Close() {
+	p.cancel()
+	<-p.done
+}
+
+/*
+Nodes returns the last known health of every node in the pool.
+*/
+func (p *Pool) Nodes() []PoolNodeStatus {
+	var statuses []PoolNodeStatus
+	for _, node := range p.nodes {
+		statuses = append(statuses, node.getStatus())
+	}
+
+	return statuses
+}
+
+/*
+SetClient overrides the client the pool uses to reach its nodes. *http.Client satisfies the client interface.
+
+Parameters:
+
+	client:
+		A pointer to an http.Client.
+*/
+func (p *Pool) SetClient(client *http.Client) {
+	p.client.Store(client)
+}
+
+// newGoMXP returns a GoMXP for host that sends its requests through the pool.
+func (p *Pool) newGoMXP(host string) *GoMXP {
+	gt := &GoMXP{host: host}
+	gt.update(func(c *config) {
+		c.client = p
+	})
+
+	return gt
+}
+
+/*
+Configure applies opts to the pool while it is in use (see GoMXP.Configure). WithTimeout, WithTLSConfig
+and WithHTTPClient replace the client the pool uses to reach its nodes, so requests keep being routed
+to healthy nodes.
+
+Parameters:
+
+	opts:
+		Options that configure the pool (e.g. WithHeader, WithTimeout).
+*/
+func (p *Pool) Configure(opts ...Option) {
+	o := newOptions(opts)
+	if client := o.newClient(p.httpClient()); client != nil {
+		p.client.Store(client)
+	}
+
+	o.client, o.timeout, o.tlsConfig = nil, 0, nil
+	p.GoMXP.apply(o)
+}
+
+func (p *Pool) httpClient() client {
+	return p.client.Load().(client)
+}
+
+// streamClient routes streams like any other request, through the pool client without its timeout.
+func (p *Pool) streamClient() client {
+	return poolStreamClient{p}
+}
+
+type poolStreamClient struct {
+	p *Pool
+}
+
+func (s poolStreamClient) Do(req *http.Request) (*http.Response, error) {
+	return s.p.do(req, withoutTimeout(s.p.httpClient()))
+}
+
+func (s poolStreamClient) CloseIdleConnections() {
+	s.p.CloseIdleConnections()
+}
+
+/*
+Do sends req to a healthy node, failing over to the next node if an idempotent request
+could not be completed.
+*/
+func (p *Pool) Do(req *http.Request) (*http.Response, error) {
+	return p.do(req, p.httpClient())
+}
+
+func (p *Pool) do(req *http.Request, httpClient client) (*http.Response, error) {
+	if p.isHealthCheck(req) {
+		return httpClient.Do(req)
+	}
+
+	nodes := p.candidates()
+	idempotent := isIdempotent(req)
+
+	var lastErr error
+	for i, node := range nodes {
+		r := node.rewrite(req, p.GoMXP.host)
+		if i > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to rewind request body")
+			}
+			r.Body = body
+		}
+
+		resp, err := httpClient.Do(r)
+		if err == nil && !isNodeFailure(resp.StatusCode) {
+			return resp, nil
+		}
+
+		if req.Context().Err() != nil || !idempotent || i == len(nodes)-1 {
+			return resp, err
+		}
+
+		if err == nil {
+			resp.Body.Close()
+			err = errors.Errorf("node responded with code %d", resp.StatusCode)
+		}
+		node.markUnhealthy(err)
+		lastErr = err
+	}
+
+	return nil, errors.Wrap(lastErr, "no node could complete the request")
+}
+
+/*
+CloseIdleConnections closes the idle connections of the client used to reach the nodes.
+*/
+func (p *Pool) CloseIdleConnections() {
+	p.httpClient().CloseIdleConnections()
+}
+
+func (p *Pool) monitor(ctx context.Context) {
+	defer close(p.done)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			p.checkHealth(ctx)
+		}
+	}
+}
+
+func (p *Pool) checkHealth(ctx context.Context) {
+	var wg sync.WaitGroup
+	statuses := make([]PoolNodeStatus, len(p.nodes))
+	for i, node := range p.nodes {
+		wg.Add(1)
+		go func(i int, node *poolNode) {
+			defer wg.Done()
+			statuses[i] = node.check(ctx, p.interval)
+		}(i, node)
+	}
+	wg.Wait()
+
+	var highest int
+	for _, status := range statuses {
+		if status.Err == nil && status.Level > highest {
+			highest = status.Level
+		}
+	}
+
+	for i, node := range p.nodes {
+		status := statuses[i]
+		status.Healthy = status.Err == nil && highest-status.Level <= p.maxLag
+		if status.Err == nil && !status.Healthy {
+			status.Err = errors.Errorf("node is %d levels behind", highest-status.Level)
+		}
+		node.setStatus(status)
+	}
+}
+
+// candidates returns the healthy nodes starting from the next one in round robin order, or every node if none are healthy.
+func (p *Pool) candidates() []*poolNode {
+	start := int(atomic.AddUint32(&p.next, 1)-1) % len(p.nodes)
+
+	var healthy, all []*poolNode
+	for i := range p.nodes {
+		node := p.nodes[(start+i)%len(p.nodes)]
+		all = append(all, node)
+		if node.getStatus().Healthy {
+			healthy = append(healthy, node)
+		}
+	}
+
+	if len(healthy) == 0 {
+		return all
+	}
+
+	return healthy
+}
+
+// isHealthCheck reports whether req is a health check, which is already addressed to its node.
+func (p *Pool) isHealthCheck(req *http.Request) bool {
+	_, ok := req.Context().Value(healthCheckKey{}).(*poolNode)
+	return ok
+}
+
+type healthCheckKey struct{}
+
+func (n *poolNode) check(ctx context.Context, timeout time.Duration) PoolNodeStatus {
+	ctx, cancel := context.WithTimeout(context.WithValue(ctx, healthCheckKey{}, n), timeout)
+	defer cancel()
+
+	status := PoolNodeStatus{Host: n.host}
+	_, err := n.gt.BootstrapContext(ctx)
+	if err != nil {
+		status.Err = errors.Wrap(err, "node is not bootstrapped")
+		return status
+	}
+
+	head, err := n.gt.HeadContext(ctx)
+	if err != nil {
+		status.Err = err
+		return status
+	}
+	status.Level = head.Header.Level
+
+	return status
+}
+
+// rewrite returns a copy of req addressed to the node instead of base.
+func (n *poolNode) rewrite(req *http.Request, base string) *http.Request {
+	r := req.Clone(req.Context())
+	u, err := url.Parse(n.host + strings.TrimPrefix(req.URL.String(), base))
+	if err == nil {
+		r.URL = u
+		r.Host = u.Host
+	}
+
+	return r
+}
+
+func (n *poolNode) markUnhealthy(err error) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status.Healthy = false
+	n.status.Err = err
+}
+
+func (n *poolNode) getStatus() PoolNodeStatus {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	return n.status
+}
+
+func (n *poolNode) setStatus(status PoolNodeStatus) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.status = status
+}
+
+// isNodeFailure reports whether an HTTP status means the node itself, rather than the request, failed.
+func isNodeFailure(code int) bool {
+	return code == http.StatusBadGateway || code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout
+}
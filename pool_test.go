@@ -0,0 +1,207 @@
+package goMXP
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var regHead = regexp.MustCompile(`\/chains\/main\/blocks\/head$`)
+
+// poolNodeMock simulates a node at the given head level. Requests that are not part of
+// the health check or initialization are handled by next.
+func poolNodeMock(t *testing.T, level int, next http.Handler) http.Handler {
+	head := getResponse(block).(*Block)
+	head.Header.Level = level
+	headResp, err := json.Marshal(head)
+	assert.Nil(t, err)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case regBoostrap.MatchString(r.URL.String()):
+			w.Write(readResponse(bootstrap))
+		case regHead.MatchString(r.URL.Path):
+			w.Write(headResp)
+		case regConstants.MatchString(r.URL.String()):
+			w.Write(readResponse(constants))
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+func Test_NewPool(t *testing.T) {
+	t.Run("handles invalid input", func(t *testing.T) {
+		_, err := NewPool(PoolInput{})
+		checkErr(t, true, "invalid input", err)
+	})
+
+	t.Run("fails if no node is reachable", func(t *testing.T) {
+		server := httptest.NewServer(blankHandler)
+		server.Close()
+
+		_, err := NewPool(PoolInput{Hosts: []string{server.URL}})
+		checkErr(t, true, "failed to initialize pool", err)
+	})
+
+	t.Run("is successful", func(t *testing.T) {
+		server := httptest.NewServer(poolNodeMock(t, 100, blankHandler))
+		defer server.Close()
+
+		pool, err := NewPool(PoolInput{Hosts: []string{server.URL}})
+		assert.Nil(t, err)
+		defer pool.Close()
+
+		assert.Equal(t, expectedConstants(t), pool.networkConstants)
+
+		var gt IFace = pool
+		assert.NotNil(t, gt)
+	})
+}
+
+func Test_Pool_health(t *testing.T) {
+	down := httptest.NewServer(blankHandler)
+	down.Close()
+
+	healthy := httptest.NewServer(poolNodeMock(t, 100, blankHandler))
+	defer healthy.Close()
+
+	lagging := httptest.NewServer(poolNodeMock(t, 90, blankHandler))
+	defer lagging.Close()
+
+	pool, err := NewPool(PoolInput{Hosts: []string{down.URL, healthy.URL, lagging.URL}, HealthCheckInterval: time.Hour})
+	assert.Nil(t, err)
+	defer pool.Close()
+
+	nodes := pool.Nodes()
+	assert.Len(t, nodes, 3)
+
+	assert.False(t, nodes[0].Healthy)
+	assert.Contains(t, nodes[0].Err.Error(), "node is not bootstrapped")
+
+	assert.True(t, nodes[1].Healthy)
+	assert.Equal(t, 100, nodes[1].Level)
+
+	assert.False(t, nodes[2].Healthy)
+	assert.Equal(t, 90, nodes[2].Level)
+	assert.Contains(t, nodes[2].Err.Error(), "node is 10 levels behind")
+
+	for i := 0; i < 3; i++ {
+		block, err := pool.Head()
+		assert.Nil(t, err)
+		assert.Equal(t, 100, block.Header.Level)
+	}
+}
+
+func Test_Pool_failover(t *testing.T) {
+	goldenOp := "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add3"
+
+	var failingCalls, workingCalls, injections int32
+	failing := httptest.NewServer(poolNodeMock(t, 100, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regInjectionOperation.MatchString(r.URL.String()) {
+			atomic.AddInt32(&injections, 1)
+		} else {
+			atomic.AddInt32(&failingCalls, 1)
+		}
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})))
+	defer failing.Close()
+
+	working := httptest.NewServer(poolNodeMock(t, 100, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if regInjectionOperation.MatchString(r.URL.String()) {
+			atomic.AddInt32(&injections, 1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		atomic.AddInt32(&workingCalls, 1)
+		w.Write(readResponse(block))
+	})))
+	defer working.Close()
+
+	pool, err := NewPool(PoolInput{Hosts: []string{failing.URL, working.URL}, HealthCheckInterval: time.Hour})
+	assert.Nil(t, err)
+	defer pool.Close()
+
+	for i := 0; i < 4; i++ {
+		_, err := pool.Block(50)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&failingCalls))
+	assert.Equal(t, int32(4), atomic.LoadInt32(&workingCalls))
+	assert.False(t, pool.Nodes()[0].Healthy)
+
+	_, err = pool.InjectionOperation(InjectionOperationInput{Operation: &goldenOp})
+	checkErr(t, true, "response returned code 503", err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&injections))
+}
+
+func Test_Pool_Configure(t *testing.T) {
+	down := httptest.NewServer(blankHandler)
+	down.Close()
+
+	var userAgents []string
+	healthy := httptest.NewServer(poolNodeMock(t, 100, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userAgents = append(userAgents, r.UserAgent())
+		w.Write(readResponse(block))
+	})))
+	defer healthy.Close()
+
+	pool, err := NewPool(PoolInput{Hosts: []string{down.URL, healthy.URL}, HealthCheckInterval: time.Hour})
+	assert.Nil(t, err)
+	defer pool.Close()
+
+	pool.Configure(WithTimeout(5*time.Second), WithUserAgent("pool"))
+	pool.Configure(WithTLSConfig(nil), WithTimeout(time.Second))
+	pool.Configure(WithHTTPClient(&http.Client{Timeout: 5 * time.Second}))
+
+	for i := 0; i < 4; i++ {
+		_, err := pool.Block(50)
+		assert.Nil(t, err)
+	}
+	assert.Equal(t, []string{"pool", "pool", "pool", "pool"}, userAgents)
+	assert.Equal(t, pool, pool.GoMXP.config().client)
+}
+
+func Test_Pool_streams(t *testing.T) {
+	node := httptest.NewServer(poolNodeMock(t, 100, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !regMonitorHeads.MatchString(r.URL.String()) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write(mockHead("BLa", 1))
+		w.(http.Flusher).Flush()
+		time.Sleep(300 * time.Millisecond)
+		w.Write(mockHead("BLb", 2))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})))
+	defer node.Close()
+
+	pool, err := NewPool(PoolInput{Hosts: []string{node.URL}, HealthCheckInterval: time.Hour})
+	assert.Nil(t, err)
+	defer pool.Close()
+	pool.Configure(WithTimeout(100 * time.Millisecond))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	heads, errs := pool.MonitorHeads(ctx, MonitorHeadsInput{ReconnectDelay: time.Millisecond})
+
+	for _, hash := range []string{"BLa", "BLb"} {
+		select {
+		case head := <-heads:
+			assert.Equal(t, hash, head.Hash)
+		case err := <-errs:
+			t.Fatalf("stream was cut: %v", err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for head")
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package goMXP
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+
+	validator "github.com/go-playground/validator/v10"
+	"github.com/pkg/errors"
+)
+
+/*
+QuorumInput is the input for the goMXP.NewQuorum function.
+
+Function:
+	func NewQuorum(input QuorumInput) (*Quorum, error) {}
+*/
+type QuorumInput struct {
+	// The MXP nodes to cross-check.
+	// Required.
+	Hosts []string `validate:"required,min=2"`
+
+	// The number of nodes that must return the same answer. Defaults to every node. If two answers
+	// both reach a threshold of half the nodes or less, the read fails with a QuorumError.
+	Threshold int
+}
+
+/*
+Quorum sends reads to several MXP nodes at the same block hash and only returns an answer
+once enough of them agree. Relative block IDs (e.g. head or a level) are resolved to a block
+hash with the first node before the other nodes are asked.
+*/
+type Quorum struct {
+	nodes     []*quorumNode
+	threshold int
+}
+
+type quorumNode struct {
+	host string
+	gt   *GoMXP
+}
+
+/*
+QuorumAnswer is the answer a single node gave to a quorum read.
+*/
+type QuorumAnswer struct {
+	Host  string
+	Value interface{}
+	Err   error
+}
+
+/*
+QuorumError is returned when fewer nodes than the quorum threshold agree on an answer, or when
+more than one answer reaches the threshold. It lists what every node said.
+*/
+type QuorumError struct {
+	Query     string
+	BlockHash string
+	Threshold int
+	Answers   []QuorumAnswer
+}
+
+func (q *QuorumError) Error() string {
+	var answers []string
+	for _, answer := range q.Answers {
+		if answer.Err != nil {
+			answers = append(answers, fmt.Sprintf("%s returned error '%s'", answer.Host, answer.Err.Error()))
+			continue
+		}
+
+		v, _ := json.Marshal(answer.Value)
+		if len(v) > 64 {
+			v = append(v[:64], []byte("...")...)
+		}
+		answers = append(answers, fmt.Sprintf("%s returned %s", answer.Host, string(v)))
+	}
+
+	return fmt.Sprintf("quorum of %d not reached for %s at block '%s': %s", q.Threshold, q.Query, q.BlockHash, strings.Join(answers, "; "))
+}
+
+/*
+NewQuorum returns a Quorum over the given hosts.
+
+Parameters:
+
+	input:
+		The hosts to cross-check and the number of them that must agree.
+*/
+func NewQuorum(input QuorumInput) (*Quorum, error) {
+	err := validator.New().Struct(input)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid input")
+	}
+
+	q := &Quorum{threshold: input.Threshold}
+	if q.threshold <= 0 || q.threshold > len(input.Hosts) {
+		q.threshold = len(input.Hosts)
+	}
+
+	for _, host := range input.Hosts {
+		gt, err := New(host)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to initialize quorum node '%s'", host)
+		}
+		q.nodes = append(q.nodes, &quorumNode{host: gt.host, gt: gt})
+	}
+
+	return q, nil
+}
+
+/*
+SetChain sets the chain every node of the quorum queries. See GoMXP.SetChain.
+
+Parameters:
+
+	chain:
+		main, test, or a chain ID (e.g. NetXdQprcVkpaWU).
+*/
+func (q *Quorum) SetChain(chain string) {
+	for _, node := range q.nodes {
+		node.gt.SetChain(chain)
+	}
+}
+
+/*
+Balance gives access to the balance of a contract once the quorum agrees on it.
+
+Parameters:
+
+	blockhash:
+		The hash of block (height) of which you want to make the query.
+
+	address:
+		Any MXP public address.
+*/
+func (q *Quorum) Balance(blockhash, address string) (*big.Int, error) {
+	return q.BalanceContext(context.Background(), blockhash, address)
+}
+
+// BalanceContext is Balance bound to ctx for cancellation and deadlines.
+func (q *Quorum) BalanceContext(ctx context.Context, blockhash, address string) (*big.Int, error) {
+	v, err := q.read(ctx, "balance", blockhash, func(ctx context.Context, gt *GoMXP, hash string) (interface{}, error) {
+		return gt.BalanceContext(ctx, hash, address)
+	})
+	if err != nil {
+		return big.NewInt(0), errors.Wrap(err, "failed to get balance")
+	}
+
+	return v.(*big.Int), nil
+}
+
+/*
+Counter access the counter of a contract once the quorum agrees on it.
+
+Parameters:
+
+	blockhash:
+		The hash of block (height) of which you want to make the query.
+
+	pkh:
+		The pkh (address) of the contract for the query.
+*/
+func (q *Quorum) Counter(blockhash, pkh string) (int, error) {
+	return q.CounterContext(context.Background(), blockhash, pkh)
+}
+
+// CounterContext is Counter bound to ctx for cancellation and deadlines.
+func (q *Quorum) CounterContext(ctx context.Context, blockhash, pkh string) (int, error) {
+	v, err := q.read(ctx, "counter", blockhash, func(ctx context.Context, gt *GoMXP, hash string) (interface{}, error) {
+		return gt.CounterContext(ctx, hash, pkh)
+	})
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get counter")
+	}
+
+	return v.(int), nil
+}
+
+/*
+Block gets all the information about a block once the quorum agrees on it.
+
+Parameters:
+
+	id:
+		hash = <string> : The block hash.
+		level = <int> : The block level.
+*/
+func (q *Quorum) Block(id interface{}) (*Block, error) {
+	return q.BlockContext(context.Background(), id)
+}
+
+// BlockContext is Block bound to ctx for cancellation and deadlines.
+func (q *Quorum) BlockContext(ctx context.Context, id interface{}) (*Block, error) {
+	blockID, err := idToString(id)
+	if err != nil {
+		return &Block{}, errors.Wrapf(err, "could not get block '%s'", blockID)
+	}
+
+	v, err := q.read(ctx, "block", blockID, func(ctx context.Context, gt *GoMXP, hash string) (interface{}, error) {
+		return gt.BlockContext(ctx, hash)
+	})
+	if err != nil {
+		return &Block{}, errors.Wrapf(err, "could not get block '%s'", blockID)
+	}
+
+	return v.(*Block), nil
+}
+
+/*
+Constants gets all constants once the quorum agrees on them.
+
+Parameters:
+
+	blockhash:
+		The hash of block (height) of which you want to make the query.
+*/
+func (q *Quorum) Constants(blockhash string) (Constants, error) {
+	return q.ConstantsContext(context.Background(), blockhash)
+}
+
+// ConstantsContext is Constants bound to ctx for cancellation and deadlines.
+func (q *Quorum) ConstantsContext(ctx context.Context, blockhash string) (Constants, error) {
+	v, err := q.read(ctx, "constants", blockhash, func(ctx context.Context, gt *GoMXP, hash string) (interface{}, error) {
+		return gt.ConstantsContext(ctx, hash)
+	})
+	if err != nil {
+		return Constants{}, errors.Wrap(err, "could not get network constants")
+	}
+
+	return v.(Constants), nil
+}
+
+type quorumRead func(ctx context.Context, gt *GoMXP, blockhash string) (interface{}, error)
+
+func (q *Quorum) read(ctx context.Context, query, blockID string, fn quorumRead) (interface{}, error) {
+	blockhash, err := q.resolve(ctx, blockID)
+	if err != nil {
+		return nil, err
+	}
+
+	answers := make([]QuorumAnswer, len(q.nodes))
+	var wg sync.WaitGroup
+	for i, node := range q.nodes {
+		wg.Add(1)
+		go func(i int, node *quorumNode) {
+			defer wg.Done()
+			v, err := fn(ctx, node.gt, blockhash)
+			answers[i] = QuorumAnswer{Host: node.host, Value: v, Err: err}
+		}(i, node)
+	}
+	wg.Wait()
+
+	votes := map[string][]int{}
+	var best string
+	agreed := 0
+	for i, answer := range answers {
+		if answer.Err != nil {
+			continue
+		}
+
+		v, err := json.Marshal(answer.Value)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to compare answers")
+		}
+
+		votes[string(v)] = append(votes[string(v)], i)
+		if len(votes[string(v)]) > len(votes[best]) {
+			best = string(v)
+		}
+		if len(votes[string(v)]) == q.threshold {
+			agreed++
+		}
+	}
+
+	// A threshold of half the nodes or less can be reached by two answers at once, which is no agreement.
+	if len(votes[best]) < q.threshold || agreed > 1 {
+		return nil, &QuorumError{
+			Query:     query,
+			BlockHash: blockhash,
+			Threshold: q.threshold,
+			Answers:   answers,
+		}
+	}
+
+	return answers[votes[best][0]].Value, nil
+}
+
+// resolve turns a relative block ID into the block hash the first node knows it by.
+func (q *Quorum) resolve(ctx context.Context, blockID string) (string, error) {
+	if isBlockHash(blockID) {
+		return blockID, nil
+	}
+
+	block, err := q.nodes[0].gt.BlockContext(ctx, blockID)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to resolve block '%s' with '%s'", blockID, q.nodes[0].host)
+	}
+
+	return block.Hash, nil
+}
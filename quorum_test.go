@@ -0,0 +1,140 @@
+package goMXP
+
+import (
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_NewQuorum(t *testing.T) {
+	t.Run("handles invalid input", func(t *testing.T) {
+		_, err := NewQuorum(QuorumInput{Hosts: []string{"http://127.0.0.1:8732"}})
+		checkErr(t, true, "invalid input", err)
+	})
+
+	t.Run("fails to initialize a node", func(t *testing.T) {
+		server := httptest.NewServer(gtGoldenHTTPMock(blankHandler))
+		defer server.Close()
+
+		down := httptest.NewServer(blankHandler)
+		down.Close()
+
+		_, err := NewQuorum(QuorumInput{Hosts: []string{server.URL, down.URL}})
+		checkErr(t, true, "failed to initialize quorum node", err)
+	})
+}
+
+func Test_Quorum_Balance(t *testing.T) {
+	type want struct {
+		err         bool
+		errContains string
+		balance     *big.Int
+		answers     int
+	}
+
+	cases := []struct {
+		name      string
+		handlers  []http.Handler
+		threshold int
+		want      want
+	}{
+		{
+			"is successful when every node agrees",
+			[]http.Handler{
+				gtGoldenHTTPMock(balanceHandlerMock(readResponse(balance), blankHandler)),
+				gtGoldenHTTPMock(balanceHandlerMock(readResponse(balance), blankHandler)),
+				gtGoldenHTTPMock(balanceHandlerMock(readResponse(balance), blankHandler)),
+			},
+			0,
+			want{false, "", big.NewInt(1216660108948), 0},
+		},
+		{
+			"returns disagreement when a node differs",
+			[]http.Handler{
+				gtGoldenHTTPMock(balanceHandlerMock(readResponse(balance), blankHandler)),
+				gtGoldenHTTPMock(balanceHandlerMock([]byte(`"10"`), blankHandler)),
+				gtGoldenHTTPMock(balanceHandlerMock(readResponse(balance), blankHandler)),
+			},
+			0,
+			want{true, "quorum of 3 not reached for balance", big.NewInt(0), 3},
+		},
+		{
+			"is successful when the threshold agrees",
+			[]http.Handler{
+				gtGoldenHTTPMock(balanceHandlerMock(readResponse(balance), blankHandler)),
+				gtGoldenHTTPMock(balanceHandlerMock([]byte(`"10"`), blankHandler)),
+				gtGoldenHTTPMock(balanceHandlerMock(readResponse(balance), blankHandler)),
+			},
+			2,
+			want{false, "", big.NewInt(1216660108948), 0},
+		},
+		{
+			"returns disagreement when two answers reach the threshold",
+			[]http.Handler{
+				gtGoldenHTTPMock(balanceHandlerMock(readResponse(balance), blankHandler)),
+				gtGoldenHTTPMock(balanceHandlerMock(readResponse(balance), blankHandler)),
+				gtGoldenHTTPMock(balanceHandlerMock([]byte(`"10"`), blankHandler)),
+				gtGoldenHTTPMock(balanceHandlerMock([]byte(`"10"`), blankHandler)),
+			},
+			2,
+			want{true, "quorum of 2 not reached for balance", big.NewInt(0), 4},
+		},
+		{
+			"returns disagreement when a node fails",
+			[]http.Handler{
+				gtGoldenHTTPMock(balanceHandlerMock(readResponse(balance), blankHandler)),
+				gtGoldenHTTPMock(balanceHandlerMock(readResponse(rpcerrors), blankHandler)),
+			},
+			0,
+			want{true, "returned error 'failed to get balance: rpc error (somekind)", big.NewInt(0), 2},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var hosts []string
+			for _, handler := range tt.handlers {
+				server := httptest.NewServer(handler)
+				defer server.Close()
+				hosts = append(hosts, server.URL)
+			}
+
+			q, err := NewQuorum(QuorumInput{Hosts: hosts, Threshold: tt.threshold})
+			assert.Nil(t, err)
+
+			balance, err := q.Balance(mockBlockHash, mockAddressTz1)
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			assert.Equal(t, tt.want.balance, balance)
+
+			var quorumErr *QuorumError
+			if tt.want.answers > 0 {
+				assert.True(t, errors.As(err, &quorumErr))
+				assert.Len(t, quorumErr.Answers, tt.want.answers)
+				assert.Equal(t, mockBlockHash, quorumErr.BlockHash)
+				for i, answer := range quorumErr.Answers {
+					assert.Equal(t, hosts[i], answer.Host)
+				}
+			}
+		})
+	}
+}
+
+func Test_Quorum_Counter(t *testing.T) {
+	var hosts []string
+	for i := 0; i < 2; i++ {
+		server := httptest.NewServer(gtGoldenHTTPMock(counterHandlerMock(readResponse(counter), blankHandler)))
+		defer server.Close()
+		hosts = append(hosts, server.URL)
+	}
+
+	q, err := NewQuorum(QuorumInput{Hosts: hosts})
+	assert.Nil(t, err)
+
+	c, err := q.Counter(mockBlockHash, mockAddressTz1)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, c)
+}
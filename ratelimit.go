@@ -0,0 +1,169 @@
+package goMXP
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/*
+RateLimit limits the requests GoMXP sends to its node. The limits are shared by every RPC function.
+Whatever the limits, when the node answers 429 Too Many Requests with a Retry-After header, no
+request is sent until that time.
+*/
+type RateLimit struct {
+	// Requests per second, refilling a token bucket. 0 disables the rate limit.
+	Rate float64
+
+	// The number of requests that may be sent at once after a quiet period. Defaults to 1.
+	Burst int
+
+	// The maximum number of requests in flight at the same time. 0 disables the cap.
+	MaxInFlight int
+}
+
+/*
+SetRateLimit sets the rate limit of GoMXP. Requests already waiting are not affected.
+
+Parameters:
+
+	limit:
+		The rate limit.
+*/
+func (t *GoMXP) SetRateLimit(limit RateLimit) {
+	t.limiter.set(limit)
+}
+
+/*
+WithRateLimit sets the rate limit of the GoMXP. See GoMXP.SetRateLimit.
+
+Parameters:
+
+	limit:
+		The rate limit.
+*/
+func WithRateLimit(limit RateLimit) Option {
+	return func(o *options) {
+		o.rateLimit = &limit
+	}
+}
+
+type limiter struct {
+	mu       sync.Mutex
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	paused   time.Time
+	inFlight chan struct{}
+}
+
+func (l *limiter) set(limit RateLimit) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.rate = limit.Rate
+	l.burst = math.Max(float64(limit.Burst), 1)
+	l.tokens = l.burst
+	l.last = time.Now()
+
+	l.inFlight = nil
+	if limit.MaxInFlight > 0 {
+		l.inFlight = make(chan struct{}, limit.MaxInFlight)
+	}
+}
+
+// wait blocks until a request may be sent. The returned function must be called once the request completed.
+func (l *limiter) wait(ctx context.Context) (func(), error) {
+	delay, inFlight := l.reserve()
+	if delay > 0 {
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			l.cancel()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	if inFlight == nil {
+		return func() {}, nil
+	}
+
+	select {
+	case inFlight <- struct{}{}:
+		return func() { <-inFlight }, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// reserve takes a token and returns how long to wait for it, and the in-flight slots to acquire.
+func (l *limiter) reserve() (time.Duration, chan struct{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := time.Now()
+	var delay time.Duration
+	if l.rate > 0 {
+		l.tokens = math.Min(l.burst, l.tokens+now.Sub(l.last).Seconds()*l.rate)
+		l.last = now
+		l.tokens--
+		if l.tokens < 0 {
+			delay = time.Duration(-l.tokens / l.rate * float64(time.Second))
+		}
+	}
+
+	if paused := l.paused.Sub(now); paused > delay {
+		delay = paused
+	}
+
+	return delay, l.inFlight
+}
+
+// cancel returns the token of a reservation that was not used.
+func (l *limiter) cancel() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.rate > 0 {
+		l.tokens++
+	}
+}
+
+// pause holds every request until the time given by the Retry-After header of resp, if any.
+func (l *limiter) pause(resp *http.Response) {
+	until, ok := retryAfter(resp.Header, time.Now())
+	if !ok {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if until.After(l.paused) {
+		l.paused = until
+	}
+}
+
+// retryAfter parses a Retry-After header, which is either a number of seconds or an HTTP date.
+func retryAfter(header http.Header, now time.Time) (time.Time, bool) {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return time.Time{}, false
+	}
+
+	if seconds, err := strconv.Atoi(v); err == nil && seconds >= 0 {
+		return now.Add(time.Duration(seconds) * time.Second), true
+	}
+
+	if date, err := http.ParseTime(v); err == nil {
+		return date, true
+	}
+
+	return time.Time{}, false
+}
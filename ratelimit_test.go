@@ -0,0 +1,126 @@
+package goMXP
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RateLimit(t *testing.T) {
+	t.Run("limits the request rate", func(t *testing.T) {
+		server := httptest.NewServer(balanceHandlerMock(readResponse(balance), blankHandler))
+		defer server.Close()
+
+		gt, err := New(server.URL, WithLazyConstants(), WithRateLimit(RateLimit{Rate: 20, Burst: 2}))
+		assert.Nil(t, err)
+
+		start := time.Now()
+		for i := 0; i < 6; i++ {
+			_, err := gt.Balance(mockBlockHash, mockAddressTz1)
+			assert.Nil(t, err)
+		}
+
+		// 2 requests are sent at once, and the next 4 every 50ms.
+		assert.True(t, time.Since(start) >= 190*time.Millisecond)
+	})
+
+	t.Run("caps requests in flight", func(t *testing.T) {
+		var inFlight, maxInFlight int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			n := atomic.AddInt32(&inFlight, 1)
+			defer atomic.AddInt32(&inFlight, -1)
+			for {
+				max := atomic.LoadInt32(&maxInFlight)
+				if n <= max || atomic.CompareAndSwapInt32(&maxInFlight, max, n) {
+					break
+				}
+			}
+
+			time.Sleep(20 * time.Millisecond)
+			w.Write(readResponse(balance))
+		}))
+		defer server.Close()
+
+		gt, err := New(server.URL, WithLazyConstants())
+		assert.Nil(t, err)
+		gt.SetRateLimit(RateLimit{MaxInFlight: 2})
+
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := gt.Balance(mockBlockHash, mockAddressTz1)
+				assert.Nil(t, err)
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, int32(2), atomic.LoadInt32(&maxInFlight))
+	})
+
+	t.Run("honours retry after", func(t *testing.T) {
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(http.StatusTooManyRequests)
+				return
+			}
+			w.Write(readResponse(balance))
+		}))
+		defer server.Close()
+
+		gt, err := New(server.URL, WithLazyConstants())
+		assert.Nil(t, err)
+
+		_, err = gt.Balance(mockBlockHash, mockAddressTz1)
+		checkErr(t, true, "response returned code 429", err)
+
+		start := time.Now()
+		_, err = gt.Balance(mockBlockHash, mockAddressTz1)
+		assert.Nil(t, err)
+		assert.True(t, time.Since(start) >= 900*time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		gt.limiter.pause(&http.Response{Header: http.Header{"Retry-After": []string{"1"}}})
+		_, err = gt.BalanceContext(ctx, mockBlockHash, mockAddressTz1)
+		checkErr(t, true, "failed to wait for rate limit: context deadline exceeded", err)
+	})
+}
+
+func Test_retryAfter(t *testing.T) {
+	now := time.Date(2020, 2, 25, 12, 4, 25, 0, time.UTC)
+
+	cases := []struct {
+		name   string
+		header string
+		want   time.Time
+		wantOk bool
+	}{
+		{"seconds", "120", now.Add(2 * time.Minute), true},
+		{"http date", "Tue, 25 Feb 2020 12:05:00 GMT", time.Date(2020, 2, 25, 12, 5, 0, 0, time.UTC), true},
+		{"missing", "", time.Time{}, false},
+		{"invalid", "soon", time.Time{}, false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			if tt.header != "" {
+				header.Set("Retry-After", tt.header)
+			}
+
+			until, ok := retryAfter(header, now)
+			assert.Equal(t, tt.wantOk, ok)
+			assert.True(t, tt.want.Equal(until))
+		})
+	}
+}
@@ -0,0 +1,344 @@
+package goMXP
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+/*
+Fixture is a recorded RPC request and its response, as saved by RecordingTransport.
+*/
+type Fixture struct {
+	Request  FixtureRequest  `json:"request"`
+	Response FixtureResponse `json:"response"`
+}
+
+/*
+FixtureRequest is the part of a request a ReplayTransport matches on.
+*/
+type FixtureRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+/*
+FixtureResponse is a recorded response.
+*/
+type FixtureResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+/*
+RecordingTransport is an http.RoundTripper that sends requests through another RoundTripper and saves
+every request and its response as a Fixture in a directory, for a ReplayTransport to serve later.
+Recording a request again replaces every fixture an earlier recording saved for it. Streaming RPCs
+(e.g. MonitorHeads) can not be recorded.
+
+Example:
+	recorder := goMXP.NewRecordingTransport(".fixtures", nil)
+	gt, err := goMXP.New("https://mainnet.example.com", goMXP.WithHTTPClient(&http.Client{Transport: recorder}))
+*/
+type RecordingTransport struct {
+	dir  string
+	next http.RoundTripper
+
+	mu    sync.Mutex
+	count map[string]int
+}
+
+/*
+NewRecordingTransport returns a RecordingTransport saving fixtures in dir.
+
+Parameters:
+
+	dir:
+		The directory to save fixtures in. It is created if it does not exist.
+
+	next:
+		The RoundTripper sending the requests. Defaults to http.DefaultTransport.
+*/
+func NewRecordingTransport(dir string, next http.RoundTripper) *RecordingTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	return &RecordingTransport{
+		dir:   dir,
+		next:  next,
+		count: map[string]int{},
+	}
+}
+
+/*
+RoundTrip sends req and saves it with its response. It fails if the fixture can not be saved.
+*/
+func (r *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fixtureReq, req, err := newFixtureRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response to record")
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	byts, err := json.MarshalIndent(Fixture{
+		Request: fixtureReq,
+		Response: FixtureResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       string(body),
+		},
+	}, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal fixture")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	name := fixtureRequestName(fixtureReq)
+	r.count[name]++
+
+	err = os.MkdirAll(r.dir, 0755)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create fixture directory")
+	}
+
+	if r.count[name] == 1 {
+		err = r.removeFixtures(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = ioutil.WriteFile(filepath.Join(r.dir, fixtureFileName(name, r.count[name])), byts, 0644)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to save fixture")
+	}
+
+	return resp, nil
+}
+
+// removeFixtures removes the fixtures an earlier recording saved for the request name beyond the first,
+// which the recording overwrites, so they are not replayed after the responses recorded now.
+func (r *RecordingTransport) removeFixtures(name string) error {
+	files, err := ioutil.ReadDir(r.dir)
+	if err != nil {
+		return errors.Wrap(err, "failed to list fixtures")
+	}
+
+	prefix := name + "."
+	for _, file := range files {
+		if !strings.HasPrefix(file.Name(), prefix) || !strings.HasSuffix(file.Name(), ".json") {
+			continue
+		}
+
+		if _, err := strconv.Atoi(strings.TrimSuffix(strings.TrimPrefix(file.Name(), prefix), ".json")); err != nil {
+			continue
+		}
+
+		err = os.Remove(filepath.Join(r.dir, file.Name()))
+		if err != nil {
+			return errors.Wrap(err, "failed to remove stale fixture")
+		}
+	}
+
+	return nil
+}
+
+/*
+ReplayTransport is an http.RoundTripper that serves the fixtures saved by a RecordingTransport without
+a node. Requests are matched on method, path, query and body. Identical requests recorded several times
+are answered in the order they were recorded, and the last response is repeated once they run out. A
+request that was never recorded fails with an error naming it.
+*/
+type ReplayTransport struct {
+	mu       sync.Mutex
+	fixtures map[string][]Fixture
+	served   map[string]int
+}
+
+/*
+NewReplayTransport returns a ReplayTransport serving the fixtures in dir.
+
+Parameters:
+
+	dir:
+		The directory a RecordingTransport saved fixtures in.
+*/
+func NewReplayTransport(dir string) (*ReplayTransport, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list fixtures")
+	}
+	sort.Strings(files)
+
+	type numbered struct {
+		n       int
+		fixture Fixture
+	}
+
+	found := map[string][]numbered{}
+	for _, file := range files {
+		byts, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read fixture '%s'", file)
+		}
+
+		var fixture Fixture
+		err = json.Unmarshal(byts, &fixture)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal fixture '%s'", file)
+		}
+
+		var n int
+		base := strings.TrimSuffix(filepath.Base(file), ".json")
+		if i := strings.LastIndex(base, "."); i >= 0 {
+			fmt.Sscanf(base[i+1:], "%d", &n)
+		}
+
+		name := fixtureRequestName(fixture.Request)
+		found[name] = append(found[name], numbered{n, fixture})
+	}
+
+	r := &ReplayTransport{
+		fixtures: map[string][]Fixture{},
+		served:   map[string]int{},
+	}
+	for name, fixtures := range found {
+		sort.SliceStable(fixtures, func(i, j int) bool { return fixtures[i].n < fixtures[j].n })
+		for _, f := range fixtures {
+			r.fixtures[name] = append(r.fixtures[name], f.fixture)
+		}
+	}
+
+	return r, nil
+}
+
+/*
+RoundTrip serves the recorded response to req.
+*/
+func (r *ReplayTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	fixtureReq, _, err := newFixtureRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	name := fixtureRequestName(fixtureReq)
+	fixtures, ok := r.fixtures[name]
+	i := r.served[name]
+	if ok && i < len(fixtures)-1 {
+		r.served[name]++
+	}
+	r.mu.Unlock()
+
+	if !ok {
+		return nil, errors.Errorf("no recorded response to request '%s %s' (query '%s', body '%s')", fixtureReq.Method, fixtureReq.Path, fixtureReq.Query, fixtureReq.Body)
+	}
+
+	fixture := fixtures[i]
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", fixture.Response.StatusCode, http.StatusText(fixture.Response.StatusCode)),
+		StatusCode:    fixture.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        fixture.Response.Header.Clone(),
+		Body:          ioutil.NopCloser(strings.NewReader(fixture.Response.Body)),
+		ContentLength: int64(len(fixture.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// newFixtureRequest reads the parts of req fixtures are matched on. The body is read from a copy
+// (see http.Request.GetBody) or a clone of req, which is returned to be sent in place of req.
+func newFixtureRequest(req *http.Request) (FixtureRequest, *http.Request, error) {
+	fixtureReq := FixtureRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+	}
+
+	if req.Body == nil || req.Body == http.NoBody {
+		return fixtureReq, req, nil
+	}
+
+	reader := req.Body
+	if req.GetBody != nil {
+		var err error
+		reader, err = req.GetBody()
+		if err != nil {
+			return fixtureReq, req, errors.Wrap(err, "failed to read request body")
+		}
+	}
+
+	body, err := ioutil.ReadAll(reader)
+	reader.Close()
+	if err != nil {
+		return fixtureReq, req, errors.Wrap(err, "failed to read request body")
+	}
+
+	if req.GetBody == nil {
+		req = req.Clone(req.Context())
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		req.GetBody = func() (io.ReadCloser, error) {
+			return ioutil.NopCloser(bytes.NewReader(body)), nil
+		}
+	}
+
+	var compact bytes.Buffer
+	if json.Compact(&compact, body) == nil {
+		body = compact.Bytes()
+	}
+	fixtureReq.Body = string(body)
+
+	return fixtureReq, req, nil
+}
+
+// fixtureRequestName names the fixtures of a request by its method, path and a hash of everything it is matched on.
+func fixtureRequestName(req FixtureRequest) string {
+	hash := sha256.Sum256([]byte(strings.Join([]string{req.Method, req.Path, req.Query, req.Body}, "\n")))
+
+	path := strings.Trim(strings.NewReplacer("/", "_", ".", "_").Replace(req.Path), "_")
+	if len(path) > 100 {
+		path = path[:100]
+	}
+
+	return fmt.Sprintf("%s_%s_%s", strings.ToLower(req.Method), path, hex.EncodeToString(hash[:6]))
+}
+
+func fixtureFileName(name string, n int) string {
+	if n <= 1 {
+		return fmt.Sprintf("%s.json", name)
+	}
+
+	return fmt.Sprintf("%s.%d.json", name, n)
+}
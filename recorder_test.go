@@ -0,0 +1,161 @@
+package goMXP
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RecordingTransport(t *testing.T) {
+	dir := t.TempDir()
+
+	var counter int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case regCounter.MatchString(r.URL.String()):
+			w.Write([]byte(`"` + string(rune('0'+atomic.AddInt32(&counter, 1))) + `"`))
+		case regForgeOperationWithRPC.MatchString(r.URL.String()):
+			body, _ := ioutil.ReadAll(r.Body)
+			w.Write([]byte(strconv.Quote(string(body))))
+		case r.URL.Path != "/chains/main/blocks/head" && regBlock.MatchString(r.URL.String()):
+			w.Write(readResponse(block))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write(readResponse(rpcerrors))
+		}
+	}))
+
+	gt, err := New(server.URL, WithLazyConstants(), WithHTTPClient(&http.Client{Transport: NewRecordingTransport(dir, nil)}))
+	assert.Nil(t, err)
+
+	recordedBlock, err := gt.Block(839681)
+	assert.Nil(t, err)
+
+	for _, want := range []int{1, 2} {
+		c, err := gt.Counter("head", mockAddressTz1)
+		assert.Nil(t, err)
+		assert.Equal(t, want, c)
+	}
+
+	_, err = gt.Head()
+	assert.NotNil(t, err)
+
+	var forged string
+	err = gt.RPC(http.MethodPost, "/chains/main/blocks/head/helpers/forge/operations", nil, map[string]string{"a": "b"}, &forged)
+	assert.Nil(t, err)
+
+	server.Close()
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.Len(t, files, 5)
+
+	replay, err := NewReplayTransport(dir)
+	assert.Nil(t, err)
+	gt, err = New("http://localhost:0", WithLazyConstants(), WithHTTPClient(&http.Client{Transport: replay}))
+	assert.Nil(t, err)
+
+	t.Run("replays responses", func(t *testing.T) {
+		b, err := gt.Block(839681)
+		assert.Nil(t, err)
+		assert.Equal(t, recordedBlock, b)
+
+		_, err = gt.Head()
+		checkErr(t, true, "rpc error (somekind): someerror", err)
+	})
+
+	t.Run("replays repeated requests in order", func(t *testing.T) {
+		for _, want := range []int{1, 2, 2} {
+			c, err := gt.Counter("head", mockAddressTz1)
+			assert.Nil(t, err)
+			assert.Equal(t, want, c)
+		}
+	})
+
+	t.Run("matches on body", func(t *testing.T) {
+		var v string
+		err := gt.RPC(http.MethodPost, "/chains/main/blocks/head/helpers/forge/operations", nil, []byte(`{ "a": "b" }`), &v)
+		assert.Nil(t, err)
+		assert.Equal(t, forged, v)
+
+		err = gt.RPC(http.MethodPost, "/chains/main/blocks/head/helpers/forge/operations", nil, map[string]string{"a": "c"}, &v)
+		checkErr(t, true, `no recorded response to request 'POST /chains/main/blocks/head/helpers/forge/operations' (query '', body '{"a":"c"}')`, err)
+	})
+
+	t.Run("fails on unrecorded request", func(t *testing.T) {
+		_, err := gt.Block(1)
+		checkErr(t, true, "no recorded response to request 'GET /chains/main/blocks/1'", err)
+	})
+}
+
+func Test_RecordingTransport_rerecord(t *testing.T) {
+	dir := t.TempDir()
+
+	var counter int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`"` + strconv.Itoa(int(atomic.AddInt32(&counter, 1))) + `"`))
+	}))
+	defer server.Close()
+
+	record := func(calls int) {
+		gt, err := New(server.URL, WithLazyConstants(), WithHTTPClient(&http.Client{Transport: NewRecordingTransport(dir, nil)}))
+		assert.Nil(t, err)
+		for i := 0; i < calls; i++ {
+			_, err := gt.Counter("head", mockAddressTz1)
+			assert.Nil(t, err)
+		}
+	}
+
+	record(3)
+	record(1)
+
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	assert.Len(t, files, 1)
+
+	replay, err := NewReplayTransport(dir)
+	assert.Nil(t, err)
+	gt, err := New("http://localhost:0", WithLazyConstants(), WithHTTPClient(&http.Client{Transport: replay}))
+	assert.Nil(t, err)
+
+	for _, want := range []int{4, 4} {
+		c, err := gt.Counter("head", mockAddressTz1)
+		assert.Nil(t, err)
+		assert.Equal(t, want, c)
+	}
+}
+
+func Test_newFixtureRequest(t *testing.T) {
+	t.Run("clones a request without GetBody", func(t *testing.T) {
+		body := ioutil.NopCloser(strings.NewReader(`{ "a": "b" }`))
+		req, _ := http.NewRequest(http.MethodPost, "http://localhost/path", body)
+
+		fixtureReq, sent, err := newFixtureRequest(req)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"a":"b"}`, fixtureReq.Body)
+		assert.True(t, body == req.Body)
+		assert.NotSame(t, req, sent)
+
+		byts, _ := ioutil.ReadAll(sent.Body)
+		assert.Equal(t, `{ "a": "b" }`, string(byts))
+	})
+
+	t.Run("reads a copy of the body", func(t *testing.T) {
+		req, _ := http.NewRequest(http.MethodPost, "http://localhost/path", strings.NewReader(`{ "a": "b" }`))
+		body := req.Body
+
+		fixtureReq, sent, err := newFixtureRequest(req)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"a":"b"}`, fixtureReq.Body)
+		assert.Same(t, req, sent)
+		assert.True(t, body == req.Body)
+
+		byts, _ := ioutil.ReadAll(req.Body)
+		assert.Equal(t, `{ "a": "b" }`, string(byts))
+	})
+}
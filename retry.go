@@ -0,0 +1,205 @@
+package goMXP
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+/*
+RetryPolicy configures how GoMXP retries a failed RPC request. Retries happen inside the
+transport, so every RPC function is covered. Injections (/injection/operation and /injection/block)
+are never retried unless RetryInjections is set, because resending them is not idempotent.
+
+Fields:
+
+	MaxAttempts:
+		The total number of attempts for a request, including the first one. Values below 2 disable retries.
+
+	InitialBackoff:
+		The wait before the first retry. Defaults to 100ms.
+
+	MaxBackoff:
+		The upper bound of the wait between two attempts. Defaults to 5s.
+
+	Multiplier:
+		The factor the backoff grows by after every attempt. Defaults to 2.
+
+	Jitter:
+		The fraction (0 to 1) of each backoff that is randomized.
+
+	Retryable:
+		Classifies whether a failed attempt should be retried. resp is nil on transport errors. Defaults to DefaultRetryable.
+
+	RetryInjections:
+		Opts in to retrying injections.
+
+	Notify:
+		Optional hook called after every failed attempt that is about to be retried. Middleware sees
+		every attempt, successful or not, with its number in RPCCall.Attempt.
+*/
+type RetryPolicy struct {
+	MaxAttempts     int
+	InitialBackoff  time.Duration
+	MaxBackoff      time.Duration
+	Multiplier      float64
+	Jitter          float64
+	Retryable       func(resp *http.Response, err error) bool
+	RetryInjections bool
+	Notify          func(attempt int, err error, wait time.Duration)
+}
+
+/*
+RetryError is returned when a request governed by a RetryPolicy fails. It reports how many
+attempts were made and wraps the error of the last one.
+*/
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (r *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempt(s): %s", r.Attempts, r.Err.Error())
+}
+
+// Unwrap returns the error of the last attempt.
+func (r *RetryError) Unwrap() error {
+	return r.Err
+}
+
+/*
+DefaultRetryable retries transport failures (other than a canceled or expired context) and the
+HTTP statuses 408, 429, 502, 503 and 504.
+*/
+func DefaultRetryable(resp *http.Response, err error) bool {
+	if resp == nil {
+		return err != nil && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusRequestTimeout, http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+/*
+SetRetryPolicy sets the retry policy used by every RPC request.
+
+Parameters:
+
+	policy:
+		The RetryPolicy to apply.
+*/
+func (t *GoMXP) SetRetryPolicy(policy RetryPolicy) {
+	t.update(func(c *config) {
+		c.retryPolicy = &policy
+	})
+}
+
+/*
+WithRetryPolicy sets the retry policy of the GoMXP. See GoMXP.SetRetryPolicy.
+
+Parameters:
+
+	policy:
+		The RetryPolicy to apply.
+*/
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = &policy
+	}
+}
+
+func (r *RetryPolicy) enabled(req *http.Request) bool {
+	if r == nil || r.MaxAttempts < 2 {
+		return false
+	}
+
+	return r.RetryInjections || isIdempotent(req)
+}
+
+func (r *RetryPolicy) retryable(resp *http.Response, err error) bool {
+	if r.Retryable != nil {
+		return r.Retryable(resp, err)
+	}
+
+	return DefaultRetryable(resp, err)
+}
+
+// backoff returns the wait before the retry that follows the given attempt (starting at 1).
+func (r *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max, multiplier := r.InitialBackoff, r.MaxBackoff, r.Multiplier
+	if initial <= 0 {
+		initial = 100 * time.Millisecond
+	}
+	if max <= 0 {
+		max = 5 * time.Second
+	}
+	if multiplier < 1 {
+		multiplier = 2
+	}
+
+	wait := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if wait > float64(max) {
+		wait = float64(max)
+	}
+
+	if r.Jitter > 0 {
+		jitter := math.Min(r.Jitter, 1)
+		wait -= wait * jitter * rand.Float64()
+	}
+
+	return time.Duration(wait)
+}
+
+func (t *GoMXP) doWithRetry(cfg *config, req *http.Request) ([]byte, error) {
+	policy := cfg.retryPolicy
+	for attempt := 1; ; attempt++ {
+		byts, resp, err := t.attempt(cfg, req, attempt)
+		if err == nil {
+			return byts, nil
+		}
+
+		if attempt >= policy.MaxAttempts || !policy.retryable(resp, err) {
+			return byts, &RetryError{Attempts: attempt, Err: err}
+		}
+
+		wait := policy.backoff(attempt)
+		if policy.Notify != nil {
+			policy.Notify(attempt, err, wait)
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return byts, &RetryError{Attempts: attempt, Err: req.Context().Err()}
+		case <-timer.C:
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return byts, &RetryError{Attempts: attempt, Err: errors.Wrap(err, "failed to rewind request body")}
+			}
+			req.Body = body
+		}
+	}
+}
+
+// isIdempotent reports whether a request can safely be sent more than once.
+func isIdempotent(req *http.Request) bool {
+	if req.Method != http.MethodPost {
+		return true
+	}
+
+	return !strings.Contains(req.URL.Path, "/injection/")
+}
@@ -0,0 +1,188 @@
+package goMXP
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RetryPolicy(t *testing.T) {
+	goldenOp := "a732d3520eeaa3de98d78e5e5cb6c85f72204fd46feb9f76853841d4a701add3"
+
+	type want struct {
+		err         bool
+		errContains string
+		calls       int32
+		attempts    int
+	}
+
+	cases := []struct {
+		name     string
+		policy   RetryPolicy
+		failures int32
+		status   int
+		inject   bool
+		want     want
+	}{
+		{
+			"retries transient failures until success",
+			RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+			2,
+			http.StatusServiceUnavailable,
+			false,
+			want{false, "", 3, 0},
+		},
+		{
+			"gives up after max attempts",
+			RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+			5,
+			http.StatusBadGateway,
+			false,
+			want{true, "request failed after 3 attempt(s)", 3, 3},
+		},
+		{
+			"does not retry errors that are not retryable",
+			RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+			5,
+			http.StatusInternalServerError,
+			false,
+			want{true, "request failed after 1 attempt(s)", 1, 1},
+		},
+		{
+			"uses custom retryable hook",
+			RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, Retryable: func(resp *http.Response, err error) bool {
+				return resp != nil && resp.StatusCode == http.StatusInternalServerError
+			}},
+			1,
+			http.StatusInternalServerError,
+			false,
+			want{false, "", 2, 0},
+		},
+		{
+			"does not retry injections without opt in",
+			RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond},
+			1,
+			http.StatusServiceUnavailable,
+			true,
+			want{true, "response returned code 503", 1, 0},
+		},
+		{
+			"retries injections with opt in",
+			RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, RetryInjections: true},
+			1,
+			http.StatusServiceUnavailable,
+			true,
+			want{false, "", 2, 0},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			flaky := regexp.MustCompile(`\/chains\/main\/blocks\/50|\/injection\/operation`)
+			server := httptest.NewServer(gtGoldenHTTPMock(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !flaky.MatchString(r.URL.String()) {
+					return
+				}
+
+				if atomic.AddInt32(&calls, 1) <= tt.failures {
+					w.WriteHeader(tt.status)
+					w.Write([]byte("unavailable"))
+					return
+				}
+
+				if tt.inject {
+					w.Write([]byte(`"ooYympR9wfV98X4MUHtE78NjXYRDeMTAD4ei7zEZDqoHv2rfb1M"`))
+					return
+				}
+				w.Write(readResponse(block))
+			})))
+			defer server.Close()
+
+			gt, err := New(server.URL)
+			assert.Nil(t, err)
+			gt.SetRetryPolicy(tt.policy)
+
+			var attempts []int
+			var last error
+			gt.Use(func(next RPCHandler) RPCHandler {
+				return func(call *RPCCall) {
+					next(call)
+					if flaky.MatchString(call.Request.URL.String()) {
+						attempts = append(attempts, call.Attempt)
+						last = call.Err
+					}
+				}
+			})
+
+			if tt.inject {
+				_, err = gt.InjectionOperation(InjectionOperationInput{Operation: &goldenOp})
+			} else {
+				_, err = gt.Block(50)
+			}
+
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			assert.Equal(t, tt.want.calls, atomic.LoadInt32(&calls))
+
+			var wantAttempts []int
+			for i := 1; i <= int(tt.want.calls); i++ {
+				wantAttempts = append(wantAttempts, i)
+			}
+			assert.Equal(t, wantAttempts, attempts)
+			assert.Equal(t, tt.want.err, last != nil)
+
+			var retryErr *RetryError
+			if tt.want.attempts > 0 {
+				assert.True(t, errors.As(err, &retryErr))
+				assert.Equal(t, tt.want.attempts, retryErr.Attempts)
+			}
+		})
+	}
+}
+
+func Test_RetryPolicy_backoff(t *testing.T) {
+	policy := RetryPolicy{
+		InitialBackoff: 10 * time.Millisecond,
+		MaxBackoff:     50 * time.Millisecond,
+		Multiplier:     2,
+	}
+
+	assert.Equal(t, 10*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 20*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 40*time.Millisecond, policy.backoff(3))
+	assert.Equal(t, 50*time.Millisecond, policy.backoff(4))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		wait := policy.backoff(2)
+		assert.True(t, wait > 10*time.Millisecond && wait <= 20*time.Millisecond)
+	}
+}
+
+func Test_isIdempotent(t *testing.T) {
+	cases := []struct {
+		method string
+		url    string
+		want   bool
+	}{
+		{http.MethodGet, "http://localhost/chains/main/blocks/head", true},
+		{http.MethodDelete, "http://localhost/chains/main/invalid_blocks/BLzGD63HA4RP8Fh5xEtvdQSMKa2WzJMZjQPNVUc4Rqy8Lh5BEY1", true},
+		{http.MethodPost, "http://localhost/chains/main/blocks/head/helpers/forge/operations", true},
+		{http.MethodPost, "http://localhost/injection/operation", false},
+		{http.MethodPost, "http://localhost/injection/block", false},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.method+" "+tt.url, func(t *testing.T) {
+			req, err := http.NewRequest(tt.method, tt.url, nil)
+			assert.Nil(t, err)
+			assert.Equal(t, tt.want, isIdempotent(req))
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package goMXP
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+/*
+RPC calls any RPC of the node, including the ones GoMXP does not wrap yet. The call goes through the
+same client, rate limit, retry policy, cache and middleware as every other RPC function, and errors
+returned by the node are parsed into a *RequestError.
+
+Example:
+	var version Version
+	err := gt.RPC(http.MethodGet, "/network/version", nil, nil, &version)
+
+Parameters:
+
+	method:
+		The HTTP method (e.g. http.MethodGet).
+
+	path:
+		The path of the RPC (e.g. /chains/main/blocks/head/header).
+
+	query:
+		The query parameters of the RPC, or nil.
+
+	body:
+		The body of the RPC, or nil. A []byte or json.RawMessage is sent as is, anything else is encoded to JSON.
+
+	out:
+		Where to decode the response, or nil to discard it. A *[]byte receives the raw response,
+		anything else is decoded from JSON.
+*/
+func (t *GoMXP) RPC(method, path string, query url.Values, body, out interface{}) error {
+	return t.RPCContext(context.Background(), method, path, query, body, out)
+}
+
+// RPCContext is RPC bound to ctx for cancellation and deadlines.
+func (t *GoMXP) RPCContext(ctx context.Context, method, path string, query url.Values, body, out interface{}) error {
+	if !strings.HasPrefix(path, "/") {
+		path = fmt.Sprintf("/%s", path)
+	}
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		reader = bytes.NewReader(b)
+	case json.RawMessage:
+		reader = bytes.NewReader(b)
+	default:
+		v, err := json.Marshal(body)
+		if err != nil {
+			return errors.Wrapf(err, "failed to marshal body of rpc '%s %s'", method, path)
+		}
+		reader = bytes.NewReader(v)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", t.host, path), reader)
+	if err != nil {
+		return errors.Wrapf(err, "failed to construct request for rpc '%s %s'", method, path)
+	}
+
+	if len(query) > 0 {
+		q := req.URL.Query()
+		for key, values := range query {
+			for _, value := range values {
+				q.Add(key, value)
+			}
+		}
+		req.URL.RawQuery = q.Encode()
+	}
+
+	resp, err := t.do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call rpc '%s %s'", method, path)
+	}
+
+	switch o := out.(type) {
+	case nil:
+	case *[]byte:
+		*o = append([]byte(nil), resp...)
+	default:
+		err = json.Unmarshal(resp, out)
+		if err != nil {
+			return errors.Wrapf(err, "failed to unmarshal response of rpc '%s %s'", method, path)
+		}
+	}
+
+	return nil
+}
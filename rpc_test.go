@@ -0,0 +1,137 @@
+package goMXP
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_RPC(t *testing.T) {
+	goldenVersion := getResponse(version).(Version)
+
+	type input struct {
+		method string
+		path   string
+		query  url.Values
+		body   interface{}
+	}
+
+	type want struct {
+		err         bool
+		errContains string
+		request     string
+		body        string
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		w.Header().Set("X-Request", r.Method+" "+r.URL.String())
+		w.Header().Set("X-Body", string(body))
+
+		switch {
+		case regVersions.MatchString(r.URL.String()):
+			w.Write(readResponse(version))
+		case r.URL.Path == "/junk":
+			w.Write([]byte(`junk`))
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write(readResponse(rpcerrors))
+		}
+	}))
+	defer server.Close()
+
+	var calls []RPCCall
+	gt, err := New(server.URL, WithLazyConstants(), WithMiddleware(func(next RPCHandler) RPCHandler {
+		return func(call *RPCCall) {
+			next(call)
+			calls = append(calls, *call)
+		}
+	}))
+	assert.Nil(t, err)
+
+	cases := []struct {
+		name  string
+		input input
+		want  want
+	}{
+		{
+			"is successful",
+			input{http.MethodGet, "/network/version", nil, nil},
+			want{false, "", "GET /network/version", ""},
+		},
+		{
+			"sends query and json body",
+			input{http.MethodPost, "network/version", url.Values{"async": []string{"true"}}, map[string]string{"key": "value"}},
+			want{false, "", "POST /network/version?async=true", `{"key":"value"}`},
+		},
+		{
+			"sends raw body",
+			input{http.MethodPost, "/network/version", nil, []byte(`"raw"`)},
+			want{false, "", "POST /network/version", `"raw"`},
+		},
+		{
+			"returns rpc error",
+			input{http.MethodGet, "/chains/main/blocks/head/header", nil, nil},
+			want{true, "failed to call rpc 'GET /chains/main/blocks/head/header': rpc error (somekind): someerror", "GET /chains/main/blocks/head/header", ""},
+		},
+		{
+			"fails to unmarshal",
+			input{http.MethodGet, "/junk", nil, nil},
+			want{true, "failed to unmarshal response of rpc 'GET /junk'", "GET /junk", ""},
+		},
+		{
+			"fails to marshal body",
+			input{http.MethodPost, "/network/version", nil, make(chan int)},
+			want{true, "failed to marshal body of rpc 'POST /network/version'", "", ""},
+		},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			calls = nil
+
+			var v Version
+			err := gt.RPC(tt.input.method, tt.input.path, tt.input.query, tt.input.body, &v)
+			checkErr(t, tt.want.err, tt.want.errContains, err)
+			if !tt.want.err {
+				assert.Equal(t, goldenVersion, v)
+			}
+
+			if tt.want.request != "" {
+				assert.Len(t, calls, 1)
+				assert.Equal(t, tt.want.request, calls[0].Response.Header.Get("X-Request"))
+				assert.Equal(t, tt.want.body, calls[0].Response.Header.Get("X-Body"))
+			} else {
+				assert.Len(t, calls, 0)
+			}
+		})
+	}
+
+	t.Run("returns raw bytes", func(t *testing.T) {
+		var raw []byte
+		err := gt.RPC(http.MethodGet, "/network/version", nil, nil, &raw)
+		assert.Nil(t, err)
+		assert.Equal(t, readResponse(version), raw)
+
+		var msg json.RawMessage
+		err = gt.RPC(http.MethodGet, "/network/version", nil, nil, &msg)
+		assert.Nil(t, err)
+		assert.JSONEq(t, string(readResponse(version)), string(msg))
+
+		err = gt.RPC(http.MethodGet, "/network/version", nil, nil, nil)
+		assert.Nil(t, err)
+	})
+
+	t.Run("exposes request error", func(t *testing.T) {
+		err := gt.RPC(http.MethodGet, "/chains/main/blocks/head/header", nil, nil, nil)
+		var reqErr *RequestError
+		assert.True(t, errors.As(err, &reqErr))
+		assert.Equal(t, http.StatusInternalServerError, reqErr.StatusCode)
+	})
+}
@@ -0,0 +1,202 @@
+package goMXP
+
+import (
+	"crypto/tls"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// config is the part of GoMXP that can be changed while requests are in flight. A stored config is
+// never modified: every request uses the config it started with, and setters store a modified copy.
+type config struct {
+	client      client
+	chain       string
+	headers     http.Header
+	middleware  []Middleware
+	cache       Cache
+	retryPolicy *RetryPolicy
+}
+
+// config returns the current config of GoMXP.
+func (t *GoMXP) config() *config {
+	if c, ok := t.cfg.Load().(*config); ok {
+		return c
+	}
+
+	return &config{}
+}
+
+// update atomically replaces the config of GoMXP with a copy modified by fn.
+func (t *GoMXP) update(fn func(c *config)) {
+	t.cfgMu.Lock()
+	defer t.cfgMu.Unlock()
+
+	c := *t.config()
+	fn(&c)
+	t.cfg.Store(&c)
+}
+
+/*
+Configure applies opts to GoMXP while it is in use. The options are applied at once: a request
+either sees all of them or none of them. WithTimeout and WithTLSConfig replace the client, and with it
+its idle connections. WithLazyConstants has no effect.
+
+Parameters:
+
+	opts:
+		Options that configure the GoMXP (e.g. WithHeader, WithCache).
+*/
+func (t *GoMXP) Configure(opts ...Option) {
+	t.configure(opts)
+}
+
+func (t *GoMXP) configure(opts []Option) options {
+	o := newOptions(opts)
+	t.apply(o)
+	return o
+}
+
+func newOptions(opts []Option) options {
+	o := options{headers: http.Header{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return o
+}
+
+// newClient returns the client that replaces current under the options, or nil if current is kept.
+func (o *options) newClient(current client) client {
+	switch {
+	case o.client != nil:
+		return o.client
+	case current == nil || o.timeout > 0 || o.tlsConfig != nil:
+		timeout := o.timeout
+		if timeout <= 0 {
+			timeout = 10 * time.Second
+		}
+		return newHTTPClient(timeout, o.tlsConfig)
+	}
+
+	return nil
+}
+
+// apply stores the options in the config of GoMXP at once.
+func (t *GoMXP) apply(o options) {
+	t.update(func(c *config) {
+		if client := o.newClient(c.client); client != nil {
+			c.client = client
+		}
+
+		if len(o.headers) > 0 {
+			headers := c.headers.Clone()
+			if headers == nil {
+				headers = http.Header{}
+			}
+			for key, values := range o.headers {
+				headers[key] = values
+			}
+			c.headers = headers
+		}
+
+		if len(o.middleware) > 0 {
+			c.middleware = append(append([]Middleware{}, c.middleware...), o.middleware...)
+		}
+
+		if o.cache != nil {
+			c.cache = o.cache
+		}
+
+		if o.retry != nil {
+			c.retryPolicy = o.retry
+		}
+	})
+
+	if o.rateLimit != nil {
+		t.SetRateLimit(*o.rateLimit)
+	}
+
+	if o.constants != nil {
+		t.SetConstants(*o.constants)
+	}
+}
+
+// newHTTPClient returns a client that keeps connections to the node alive, so concurrent and
+// successive requests reuse connections and TLS sessions instead of dialing for every request.
+func newHTTPClient(timeout time.Duration, tlsConfig *tls.Config) *http.Client {
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			Proxy: http.ProxyFromEnvironment,
+			DialContext: (&net.Dialer{
+				Timeout:   10 * time.Second,
+				KeepAlive: 30 * time.Second,
+			}).DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          256,
+			MaxIdleConnsPerHost:   256,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: time.Second,
+			TLSClientConfig:       tlsConfig,
+		},
+	}
+}
+
+func (c *config) setHeaders(req *http.Request) {
+	req.Header.Set("Content-Type", "application/json")
+	for key, values := range c.headers {
+		req.Header[key] = values
+	}
+}
+
+func (c *config) handler() RPCHandler {
+	handler := c.send
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		handler = c.middleware[i](handler)
+	}
+
+	return handler
+}
+
+// send sends call.Request once and records the outcome on call.
+func (c *config) send(call *RPCCall) {
+	start := time.Now()
+	defer func() {
+		call.Latency = time.Since(start)
+		call.Size = len(call.Body)
+	}()
+
+	req := call.Request
+	resp, err := c.client.Do(req)
+	if err != nil {
+		call.Err = &RequestError{
+			Method: req.Method,
+			Path:   req.URL.Path,
+			Err:    errors.Wrap(err, "failed to complete request"),
+		}
+		return
+	}
+	defer resp.Body.Close()
+
+	call.Response = resp
+	call.StatusCode = resp.StatusCode
+
+	call.Body, err = ioutil.ReadAll(resp.Body)
+	if err != nil {
+		call.Err = &RequestError{
+			Method:     req.Method,
+			Path:       req.URL.Path,
+			StatusCode: resp.StatusCode,
+			Body:       call.Body,
+			Err:        errors.Wrap(err, "could not read response body"),
+		}
+		return
+	}
+
+	call.Err = handleRPCError(req, resp.StatusCode, call.Body)
+}
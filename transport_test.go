@@ -0,0 +1,86 @@
+package goMXP
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Configure(t *testing.T) {
+	var headers atomic.Value
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers.Store(r.Header.Clone())
+		w.Write(readResponse(block))
+	}))
+	defer server.Close()
+
+	gt, err := New(server.URL, WithLazyConstants(), WithHeader("Authorization", "Bearer old"))
+	assert.Nil(t, err)
+	client := gt.config().client
+
+	var calls int32
+	gt.Configure(WithHeader("Authorization", "Bearer new"), WithMiddleware(func(next RPCHandler) RPCHandler {
+		return func(call *RPCCall) {
+			atomic.AddInt32(&calls, 1)
+			next(call)
+		}
+	}))
+
+	_, err = gt.Block(839681)
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer new", headers.Load().(http.Header).Get("Authorization"))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	assert.Equal(t, client, gt.config().client)
+
+	gt.Configure(WithTimeout(time.Minute))
+	assert.NotEqual(t, client, gt.config().client)
+	assert.Equal(t, time.Minute, gt.config().client.(*http.Client).Timeout)
+}
+
+func Test_GoMXP_concurrentUse(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write(readResponse(block))
+	}))
+	defer server.Close()
+
+	gt, err := New(server.URL, WithLazyConstants())
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, err := gt.Block(839681)
+			if err != nil {
+				errs <- err
+			}
+		}()
+
+		go func(i int) {
+			defer wg.Done()
+			switch i % 4 {
+			case 0:
+				gt.SetClient(newHTTPClient(10*time.Second, nil))
+			case 1:
+				gt.SetChain("main")
+			case 2:
+				gt.Use(func(next RPCHandler) RPCHandler { return next })
+			case 3:
+				gt.Configure(WithHeader("X-Request", "1"), WithCache(NewLRUCache(10)))
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		assert.Nil(t, err)
+	}
+}